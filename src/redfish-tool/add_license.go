@@ -5,11 +5,12 @@ import (
 	"flag"
 	"fmt"
 	redfish "git.ypbind.de/repository/go-redfish.git"
+	"io"
 	"io/ioutil"
 	"os"
 )
 
-func addLicense(r redfish.Redfish, args []string) error {
+func addLicense(r redfish.Redfish, args []string, opts *OutputOptions, out io.Writer) error {
 	argParse := flag.NewFlagSet("add-license", flag.ExitOnError)
 	var id = argParse.String("id", "", "Management board identified by ID")
 	var uuid = argParse.String("uuid", "", "Management board identified by UUID")
@@ -22,20 +23,22 @@ func addLicense(r redfish.Redfish, args []string) error {
 
 	argParse.Parse(args)
 
-	fmt.Println(r.Hostname)
+	if opts.Format == OutputText {
+		fmt.Fprintln(out, r.Hostname)
+	}
 
 	if *uuid != "" && *id != "" {
-		return errors.New("ERROR: Options -uuid and -id are mutually exclusive")
+		return usageError("ERROR: Options -uuid and -id are mutually exclusive")
 	}
 	if *uuid == "" && *id == "" {
-		return errors.New("ERROR: Required options -uuid or -id not found")
+		return usageError("ERROR: Required options -uuid or -id not found")
 	}
 
 	if *l != "" && *lf != "" {
-		return errors.New("ERROR: Options -license and -license-file are mutually exclusive")
+		return usageError("ERROR: Options -license and -license-file are mutually exclusive")
 	}
 	if *l == "" && *lf == "" {
-		return errors.New("ERROR: Mandatory options -license or -license-file are not found")
+		return usageError("ERROR: Mandatory options -license or -license-file are not found")
 	}
 
 	if *lf != "" {
@@ -74,7 +77,9 @@ func addLicense(r redfish.Redfish, args []string) error {
 	capa, found := redfish.VendorCapabilities[r.FlavorString]
 	if found {
 		if capa&redfish.HasLicense != redfish.HasLicense {
-			fmt.Println(r.Hostname)
+			if opts.Format == OutputText {
+				fmt.Fprintln(out, r.Hostname)
+			}
 			return errors.New("Vendor does not support license operations")
 		}
 	}
@@ -98,13 +103,13 @@ func addLicense(r redfish.Redfish, args []string) error {
 	if found {
 		err := r.AddLicense(mgr, ldata)
 		if err != nil {
-			return nil
+			return err
 		}
 	} else {
 		if *id != "" {
-			fmt.Fprintf(os.Stderr, "Manager with ID %s not found on %s\n", *id, r.Hostname)
+			return fmt.Errorf("ERROR: Manager with ID %s not found on %s", *id, r.Hostname)
 		} else if *uuid != "" {
-			fmt.Fprintf(os.Stderr, "Manager with UUID %s not found on %s\n", *uuid, r.Hostname)
+			return fmt.Errorf("ERROR: Manager with UUID %s not found on %s", *uuid, r.Hostname)
 		}
 	}
 
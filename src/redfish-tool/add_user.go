@@ -7,6 +7,7 @@ import (
 	redfish "git.ypbind.de/repository/go-redfish.git"
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/crypto/ssh/terminal"
+	"io"
 	"strings"
 	"syscall"
 )
@@ -25,7 +26,7 @@ func hpeParsePrivileges(privileges string) (uint, error) {
 	return result, nil
 }
 
-func addUser(r redfish.Redfish, args []string) error {
+func addUser(r redfish.Redfish, args []string, opts *OutputOptions, out io.Writer) error {
 	var acc redfish.AccountCreateData
 
 	argParse := flag.NewFlagSet("add-user", flag.ExitOnError)
@@ -41,11 +42,11 @@ func addUser(r redfish.Redfish, args []string) error {
 	argParse.Parse(args)
 
 	if *name == "" {
-		return errors.New("ERROR: Required options -name not found")
+		return usageError("ERROR: Required options -name not found")
 	}
 
 	if *password != "" && *passwordFile != "" {
-		return fmt.Errorf("ERROR: -password and -password-file are mutually exclusive")
+		return usageError("ERROR: -password and -password-file are mutually exclusive")
 	}
 
 	// Initialize session
@@ -98,6 +99,7 @@ func addUser(r redfish.Redfish, args []string) error {
 	// ask for password ?
 	if *password == "" {
 		if *passwordFile == "" {
+			terminalLock.Lock()
 			fmt.Printf("Password for %s: ", *name)
 			rawPass, _ := terminal.ReadPassword(int(syscall.Stdin))
 			pass1 := strings.Replace(strings.Replace(strings.Replace(string(rawPass), "\r", "", -1), "\n", "", -1), "\t", "", -1)
@@ -107,6 +109,7 @@ func addUser(r redfish.Redfish, args []string) error {
 			rawPass, _ = terminal.ReadPassword(int(syscall.Stdin))
 			pass2 := strings.Replace(strings.Replace(strings.Replace(string(rawPass), "\r", "", -1), "\n", "", -1), "\t", "", -1)
 			fmt.Println()
+			terminalLock.Unlock()
 
 			if pass1 != pass2 {
 				return fmt.Errorf("ERROR: Passwords does not match for user %s", *name)
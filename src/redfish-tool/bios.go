@@ -0,0 +1,151 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	redfish "git.ypbind.de/repository/go-redfish.git"
+)
+
+// BiosData - BIOS attributes of a system, Pending contains the attributes which will be changed on the next reboot
+type BiosData struct {
+	SystemID          string                 `json:"SystemId"`
+	ID                *string                `json:"Id"`
+	Name              *string                `json:"Name"`
+	AttributeRegistry *string                `json:"AttributeRegistry"`
+	Attributes        map[string]interface{} `json:"Attributes"`
+	Pending           map[string]interface{} `json:"Pending"`
+	SettingsEndpoint  *string                `json:"-"`
+	ResetBios         *string                `json:"-"`
+	SelfEndpoint      *string
+}
+
+type biosResource struct {
+	ID                *string                `json:"Id"`
+	Name              *string                `json:"Name"`
+	AttributeRegistry *string                `json:"AttributeRegistry"`
+	Attributes        map[string]interface{} `json:"Attributes"`
+	Settings          *struct {
+		SettingsObject *OData `json:"SettingsObject"`
+	} `json:"@Redfish.Settings"`
+	Actions struct {
+		ResetBios *struct {
+			Target *string `json:"target"`
+		} `json:"#Bios.ResetBios"`
+	} `json:"Actions"`
+}
+
+// fetchBios - BIOS attributes and pending changes of a system
+func fetchBios(r redfish.Redfish, sys *redfish.SystemData) (*BiosData, error) {
+	var system systemResource
+	var bios biosResource
+	var settings biosResource
+	var sysID string
+
+	if sys.ID != nil {
+		sysID = *sys.ID
+	}
+
+	if sys.SelfEndpoint == nil {
+		return nil, fmt.Errorf("ERROR: No endpoint for system %s on %s", sysID, r.Hostname)
+	}
+
+	err := redfishGet(r, *sys.SelfEndpoint, &system)
+	if err != nil {
+		return nil, err
+	}
+
+	if system.Bios == nil || system.Bios.ID == nil {
+		return nil, fmt.Errorf("ERROR: No BIOS resource found for system %s on %s", sysID, r.Hostname)
+	}
+
+	err = redfishGet(r, *system.Bios.ID, &bios)
+	if err != nil {
+		return nil, err
+	}
+
+	ep := *system.Bios.ID
+	result := BiosData{
+		SystemID:          sysID,
+		ID:                bios.ID,
+		Name:              bios.Name,
+		AttributeRegistry: bios.AttributeRegistry,
+		Attributes:        bios.Attributes,
+		Pending:           make(map[string]interface{}),
+		SelfEndpoint:      &ep,
+	}
+	if result.Attributes == nil {
+		result.Attributes = make(map[string]interface{})
+	}
+
+	if bios.Actions.ResetBios != nil {
+		result.ResetBios = bios.Actions.ResetBios.Target
+	}
+
+	// the settings object is optional, fall back to the common location
+	settingsEndpoint := strings.TrimRight(ep, "/") + "/Settings"
+	if bios.Settings != nil && bios.Settings.SettingsObject != nil && bios.Settings.SettingsObject.ID != nil {
+		settingsEndpoint = *bios.Settings.SettingsObject.ID
+	}
+	result.SettingsEndpoint = &settingsEndpoint
+
+	// some vendors only return the changed attributes, others all attributes of the settings object
+	if redfishGet(r, settingsEndpoint, &settings) == nil {
+		for name, value := range settings.Attributes {
+			current, found := result.Attributes[name]
+			if !found || !reflect.DeepEqual(current, value) {
+				result.Pending[name] = value
+			}
+		}
+	}
+
+	return &result, nil
+}
+
+// biosValue - string representation of a BIOS attribute value
+func biosValue(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case bool:
+		return strconv.FormatBool(value)
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+
+	str, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(str)
+}
+
+// setBiosAttributes - write attributes to the settings object, they will be applied on the next reboot
+func setBiosAttributes(r redfish.Redfish, bios *BiosData, attributes map[string]interface{}, applyTime string) error {
+	var payload = make(map[string]interface{})
+
+	payload["Attributes"] = attributes
+	if applyTime != "" {
+		payload["@Redfish.SettingsApplyTime"] = map[string]string{
+			"ApplyTime": applyTime,
+		}
+	}
+
+	_, err := redfishSend(r, "PATCH", *bios.SettingsEndpoint, payload)
+	return err
+}
+
+// resetBios - reset BIOS attributes to their default values
+func resetBios(r redfish.Redfish, bios *BiosData) error {
+	if bios.ResetBios == nil {
+		return fmt.Errorf("ERROR: Bios.ResetBios is not provided for system %s on %s", bios.SystemID, r.Hostname)
+	}
+
+	_, err := redfishSend(r, "POST", *bios.ResetBios, make(map[string]interface{}))
+	return err
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	redfish "git.ypbind.de/repository/go-redfish.git"
+)
+
+// BiosAttributeDefinition - definition of a BIOS attribute in the attribute registry
+type BiosAttributeDefinition struct {
+	AttributeName   *string  `json:"AttributeName"`
+	Type            *string  `json:"Type"`
+	ReadOnly        *bool    `json:"ReadOnly"`
+	LowerBound      *float64 `json:"LowerBound"`
+	UpperBound      *float64 `json:"UpperBound"`
+	ScalarIncrement *float64 `json:"ScalarIncrement"`
+	MinLength       *float64 `json:"MinLength"`
+	MaxLength       *float64 `json:"MaxLength"`
+	ValueExpression *string  `json:"ValueExpression"`
+	Value           []struct {
+		ValueName *string `json:"ValueName"`
+	} `json:"Value"`
+}
+
+type registryFileResource struct {
+	ID       *string `json:"Id"`
+	Registry *string `json:"Registry"`
+	Location []struct {
+		Language *string `json:"Language"`
+		URI      *string `json:"Uri"`
+	} `json:"Location"`
+}
+
+type attributeRegistryResource struct {
+	RegistryEntries struct {
+		Attributes []BiosAttributeDefinition `json:"Attributes"`
+	} `json:"RegistryEntries"`
+}
+
+// matchRegistry - registry file describes the registry name, e.g. BiosAttributeRegistry.v1_0_3 is described by
+// the registry file BiosAttributeRegistry.v1_0_3 or BiosAttributeRegistry
+func matchRegistry(file *registryFileResource, name string) bool {
+	if file.ID != nil && (*file.ID == name || *file.ID == strings.SplitN(name, ".", 2)[0]) {
+		return true
+	}
+	if file.Registry != nil && *file.Registry == name {
+		return true
+	}
+	return false
+}
+
+// fetchAttributeRegistry - definitions of the attributes of the attribute registry name from the Registries collection
+func fetchAttributeRegistry(r redfish.Redfish, name string) (map[string]*BiosAttributeDefinition, error) {
+	var registry attributeRegistryResource
+	var location string
+
+	endpoint, err := serviceEndpoint(r, "Registries")
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := collectionMembers(r, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, member := range members {
+		var file registryFileResource
+
+		err = redfishGet(r, member, &file)
+		if err != nil {
+			return nil, err
+		}
+
+		if !matchRegistry(&file, name) {
+			continue
+		}
+
+		// prefer the english version of the registry
+		for _, loc := range file.Location {
+			if loc.URI == nil {
+				continue
+			}
+			if location == "" || (loc.Language != nil && strings.HasPrefix(strings.ToLower(*loc.Language), "en")) {
+				location = *loc.URI
+			}
+		}
+		if location != "" {
+			break
+		}
+	}
+
+	if location == "" {
+		return nil, fmt.Errorf("ERROR: Attribute registry %s not found on %s", name, r.Hostname)
+	}
+
+	err = redfishGet(r, location, &registry)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]*BiosAttributeDefinition)
+	for i := range registry.RegistryEntries.Attributes {
+		def := &registry.RegistryEntries.Attributes[i]
+		if def.AttributeName != nil {
+			result[*def.AttributeName] = def
+		}
+	}
+
+	return result, nil
+}
+
+// validateBiosAttribute - check value against the definition of the attribute and convert it to the type of the attribute
+func validateBiosAttribute(name string, value interface{}, def *BiosAttributeDefinition) (interface{}, error) {
+	var atype string
+
+	if def.ReadOnly != nil && *def.ReadOnly {
+		return nil, fmt.Errorf("attribute %s is read-only", name)
+	}
+
+	if def.Type != nil {
+		atype = *def.Type
+	}
+
+	switch atype {
+	case "Enumeration":
+		var allowed []string
+
+		str := biosValue(value)
+		for _, v := range def.Value {
+			if v.ValueName == nil {
+				continue
+			}
+			if *v.ValueName == str {
+				return str, nil
+			}
+			allowed = append(allowed, *v.ValueName)
+		}
+		return nil, fmt.Errorf("invalid value %s for attribute %s, allowed values are %s", str, name, strings.Join(allowed, ", "))
+
+	case "Integer":
+		var num float64
+
+		switch v := value.(type) {
+		case int64:
+			num = float64(v)
+		case float64:
+			num = v
+		case string:
+			i, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("attribute %s requires an integer value, got %s", name, v)
+			}
+			num = float64(i)
+		default:
+			return nil, fmt.Errorf("attribute %s requires an integer value, got %s", name, biosValue(value))
+		}
+
+		if num != math.Trunc(num) {
+			return nil, fmt.Errorf("attribute %s requires an integer value, got %s", name, biosValue(value))
+		}
+		if def.LowerBound != nil && num < *def.LowerBound {
+			return nil, fmt.Errorf("value %s of attribute %s is lower than %s", biosValue(num), name, biosValue(*def.LowerBound))
+		}
+		if def.UpperBound != nil && num > *def.UpperBound {
+			return nil, fmt.Errorf("value %s of attribute %s is greater than %s", biosValue(num), name, biosValue(*def.UpperBound))
+		}
+		if def.ScalarIncrement != nil && *def.ScalarIncrement > 0 {
+			var lower float64
+			if def.LowerBound != nil {
+				lower = *def.LowerBound
+			}
+			if math.Mod(num-lower, *def.ScalarIncrement) != 0 {
+				return nil, fmt.Errorf("value %s of attribute %s is not a multiple of %s", biosValue(num), name, biosValue(*def.ScalarIncrement))
+			}
+		}
+		return int64(num), nil
+
+	case "Boolean":
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			if v == "true" || v == "false" {
+				return v == "true", nil
+			}
+		}
+		return nil, fmt.Errorf("attribute %s requires true or false, got %s", name, biosValue(value))
+
+	case "String", "Password":
+		str := biosValue(value)
+		if def.MinLength != nil && float64(len(str)) < *def.MinLength {
+			return nil, fmt.Errorf("value of attribute %s is shorter than %s characters", name, biosValue(*def.MinLength))
+		}
+		if def.MaxLength != nil && float64(len(str)) > *def.MaxLength {
+			return nil, fmt.Errorf("value of attribute %s is longer than %s characters", name, biosValue(*def.MaxLength))
+		}
+		if def.ValueExpression != nil && *def.ValueExpression != "" {
+			re, err := regexp.Compile(*def.ValueExpression)
+			if err == nil && !re.MatchString(str) {
+				return nil, fmt.Errorf("value of attribute %s doesn't match %s", name, *def.ValueExpression)
+			}
+		}
+		return str, nil
+	}
+
+	// unknown types are passed as they are
+	return value, nil
+}
+
+// validateBiosAttributes - validate all attributes against the attribute registry, all rejected attributes are reported
+func validateBiosAttributes(r redfish.Redfish, registry map[string]*BiosAttributeDefinition, attributes map[string]interface{}) (map[string]interface{}, error) {
+	var result = make(map[string]interface{})
+	var rejected []string
+	var names []string
+
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		def, found := registry[name]
+		if !found {
+			rejected = append(rejected, fmt.Sprintf("unknown attribute %s", name))
+			continue
+		}
+
+		value, err := validateBiosAttribute(name, attributes[name], def)
+		if err != nil {
+			rejected = append(rejected, err.Error())
+			continue
+		}
+		result[name] = value
+	}
+
+	if len(rejected) > 0 {
+		return nil, fmt.Errorf("ERROR: Invalid BIOS attributes for %s: %s", r.Hostname, strings.Join(rejected, "; "))
+	}
+
+	return result, nil
+}
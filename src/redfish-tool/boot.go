@@ -0,0 +1,316 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	redfish "git.ypbind.de/repository/go-redfish.git"
+)
+
+// bootResource - boot settings of a system
+type bootResource struct {
+	BootSourceOverrideTarget               *string  `json:"BootSourceOverrideTarget"`
+	BootSourceOverrideTargetAllowableValue []string `json:"BootSourceOverrideTarget@Redfish.AllowableValues"`
+	BootSourceOverrideEnabled              *string  `json:"BootSourceOverrideEnabled"`
+	BootSourceOverrideMode                 *string  `json:"BootSourceOverrideMode"`
+	UefiTargetBootSourceOverride           *string  `json:"UefiTargetBootSourceOverride"`
+	BootOrder                              []string `json:"BootOrder"`
+	BootOptions                            *OData   `json:"BootOptions"`
+}
+
+type systemBootResource struct {
+	Boot     bootResource `json:"Boot"`
+	Settings *struct {
+		SettingsObject *OData `json:"SettingsObject"`
+	} `json:"@Redfish.Settings"`
+}
+
+// BootOptionData - boot option of a system
+type BootOptionData struct {
+	ID                  *string `json:"Id"`
+	Name                *string `json:"Name"`
+	DisplayName         *string `json:"DisplayName"`
+	BootOptionReference *string `json:"BootOptionReference"`
+	Alias               *string `json:"Alias"`
+	UefiDevicePath      *string `json:"UefiDevicePath"`
+	BootOptionEnabled   *bool   `json:"BootOptionEnabled"`
+	SelfEndpoint        *string
+}
+
+// BootOrderData - persistent boot order of a system, Options contains the boot option of every entry
+// of BootOrder (without DisplayName if the system doesn't provide boot options), Available contains
+// the boot options of the system which are not part of BootOrder
+type BootOrderData struct {
+	SystemID         string           `json:"SystemId"`
+	BootOrder        []string         `json:"BootOrder"`
+	Options          []BootOptionData `json:"BootOptions"`
+	Available        []BootOptionData `json:"AvailableBootOptions"`
+	SettingsEndpoint *string          `json:"-"`
+}
+
+type systemResetResource struct {
+	Actions struct {
+		Reset *struct {
+			ResetTypes []string `json:"ResetType@Redfish.AllowableValues"`
+			ActionInfo *string  `json:"@Redfish.ActionInfo"`
+		} `json:"#ComputerSystem.Reset"`
+	} `json:"Actions"`
+}
+
+type actionInfoResource struct {
+	Parameters []struct {
+		Name            *string  `json:"Name"`
+		AllowableValues []string `json:"AllowableValues"`
+	} `json:"Parameters"`
+}
+
+// bootOverrideEnabled - supported values of BootSourceOverrideEnabled
+var bootOverrideEnabled = []string{"Once", "Continuous", "Disabled"}
+
+// bootOverrideMode - supported values of BootSourceOverrideMode
+var bootOverrideMode = []string{"UEFI", "Legacy"}
+
+// matchValue - value from allowed with the same name as value (case-insensitive), empty if not allowed
+func matchValue(value string, allowed []string) string {
+	for _, a := range allowed {
+		if strings.EqualFold(a, value) {
+			return a
+		}
+	}
+	return ""
+}
+
+// fetchBoot - boot settings of a system
+func fetchBoot(r redfish.Redfish, sys *redfish.SystemData) (*bootResource, error) {
+	var system systemBootResource
+
+	if sys.SelfEndpoint == nil {
+		return nil, fmt.Errorf("ERROR: No endpoint for system on %s", r.Hostname)
+	}
+
+	err := redfishGet(r, *sys.SelfEndpoint, &system)
+	if err != nil {
+		return nil, err
+	}
+
+	return &system.Boot, nil
+}
+
+// fetchBootOrder - boot order of a system with the boot option of every entry
+func fetchBootOrder(r redfish.Redfish, sys *redfish.SystemData) (*BootOrderData, error) {
+	var system systemBootResource
+	var sysID string
+	var options = make(map[string]BootOptionData)
+	var references []string
+
+	if sys.ID != nil {
+		sysID = *sys.ID
+	}
+
+	if sys.SelfEndpoint == nil {
+		return nil, fmt.Errorf("ERROR: No endpoint for system %s on %s", sysID, r.Hostname)
+	}
+
+	err := redfishGet(r, *sys.SelfEndpoint, &system)
+	if err != nil {
+		return nil, err
+	}
+
+	// changes are written to the settings object if the system provides one
+	settings := *sys.SelfEndpoint
+	if system.Settings != nil && system.Settings.SettingsObject != nil && system.Settings.SettingsObject.ID != nil {
+		settings = *system.Settings.SettingsObject.ID
+	}
+
+	result := BootOrderData{
+		SystemID:         sysID,
+		BootOrder:        system.Boot.BootOrder,
+		Options:          make([]BootOptionData, 0, len(system.Boot.BootOrder)),
+		Available:        make([]BootOptionData, 0),
+		SettingsEndpoint: &settings,
+	}
+	if result.BootOrder == nil {
+		result.BootOrder = make([]string, 0)
+	}
+
+	if system.Boot.BootOptions != nil && system.Boot.BootOptions.ID != nil {
+		members, err := collectionMembers(r, *system.Boot.BootOptions.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, member := range members {
+			var opt BootOptionData
+
+			err = redfishGet(r, member, &opt)
+			if err != nil {
+				return nil, err
+			}
+
+			ep := member
+			opt.SelfEndpoint = &ep
+			if opt.BootOptionReference != nil {
+				options[*opt.BootOptionReference] = opt
+				references = append(references, *opt.BootOptionReference)
+			}
+		}
+	}
+
+	var inOrder = make(map[string]bool)
+	for _, ref := range result.BootOrder {
+		inOrder[ref] = true
+		opt, found := options[ref]
+		if !found {
+			_ref := ref
+			opt = BootOptionData{BootOptionReference: &_ref}
+		}
+		result.Options = append(result.Options, opt)
+	}
+
+	for _, ref := range references {
+		if !inOrder[ref] {
+			result.Available = append(result.Available, options[ref])
+		}
+	}
+
+	return &result, nil
+}
+
+// fetchResetTypes - reset types supported by ComputerSystem.Reset of a system, from the action or its ActionInfo,
+// empty if the system doesn't announce them
+func fetchResetTypes(r redfish.Redfish, sys *redfish.SystemData) ([]string, error) {
+	var system systemResetResource
+	var info actionInfoResource
+
+	if sys.SelfEndpoint == nil {
+		return nil, fmt.Errorf("ERROR: No endpoint for system on %s", r.Hostname)
+	}
+
+	err := redfishGet(r, *sys.SelfEndpoint, &system)
+	if err != nil {
+		return nil, err
+	}
+
+	reset := system.Actions.Reset
+	if reset == nil {
+		return nil, fmt.Errorf("ERROR: ComputerSystem.Reset is not provided by %s", r.Hostname)
+	}
+
+	if len(reset.ResetTypes) > 0 || reset.ActionInfo == nil {
+		return reset.ResetTypes, nil
+	}
+
+	err = redfishGet(r, *reset.ActionInfo, &info)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, param := range info.Parameters {
+		if param.Name != nil && *param.Name == "ResetType" {
+			return param.AllowableValues, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// setSystemBootOverride - PATCH the boot settings of a system
+func setSystemBootOverride(r redfish.Redfish, sys *redfish.SystemData, boot map[string]interface{}) error {
+	var payload = make(map[string]interface{})
+
+	if sys.SelfEndpoint == nil {
+		return fmt.Errorf("ERROR: No endpoint for system on %s", r.Hostname)
+	}
+
+	payload["Boot"] = boot
+	_, err := redfishSend(r, "PATCH", *sys.SelfEndpoint, payload)
+	return err
+}
+
+// displayNamePattern - case-insensitive regular expression of a display name pattern, * matches any characters
+// (including /, e.g. in "UEFI Network Card: (B24/D0/F0)") and ? a single character
+func displayNamePattern(pattern string) *regexp.Regexp {
+	var expr = "(?i)^"
+
+	for _, c := range pattern {
+		switch c {
+		case '*':
+			expr += ".*"
+		case '?':
+			expr += "."
+		default:
+			expr += regexp.QuoteMeta(string(c))
+		}
+	}
+
+	return regexp.MustCompile(expr + "$")
+}
+
+// matchBootOption - item is the reference or alias of the boot option or a pattern matching the display name
+func matchBootOption(opt BootOptionData, item string) bool {
+	if opt.BootOptionReference != nil && strings.EqualFold(*opt.BootOptionReference, item) {
+		return true
+	}
+
+	if opt.Alias != nil && strings.EqualFold(*opt.Alias, item) {
+		return true
+	}
+
+	if opt.DisplayName != nil {
+		return displayNamePattern(item).MatchString(*opt.DisplayName)
+	}
+
+	return false
+}
+
+// resolveBootOrder - new boot order from references or display name patterns of all boot options of the system,
+// the current order is kept for entries matched by the same pattern and boot options not in the current boot order
+// follow them. Entries of the current boot order not in the new order are returned as dropped
+func resolveBootOrder(order *BootOrderData, items []string) ([]string, []string, error) {
+	var result []string
+	var dropped []string
+	var used = make(map[string]bool)
+
+	candidates := append(append([]BootOptionData{}, order.Options...), order.Available...)
+
+	for _, item := range items {
+		var matched bool
+
+		for _, opt := range candidates {
+			if !matchBootOption(opt, item) {
+				continue
+			}
+
+			matched = true
+			if !used[*opt.BootOptionReference] {
+				used[*opt.BootOptionReference] = true
+				result = append(result, *opt.BootOptionReference)
+			}
+		}
+
+		if !matched {
+			return nil, nil, fmt.Errorf("ERROR: No boot option of system %s matches %s", order.SystemID, item)
+		}
+	}
+
+	for _, ref := range order.BootOrder {
+		if !used[ref] {
+			dropped = append(dropped, ref)
+		}
+	}
+
+	return result, dropped, nil
+}
+
+// setSystemBootOrder - write the persistent boot order of a system
+func setSystemBootOrder(r redfish.Redfish, order *BootOrderData, bootOrder []string) error {
+	var payload = make(map[string]interface{})
+
+	payload["Boot"] = map[string]interface{}{
+		"BootOrder": bootOrder,
+	}
+
+	_, err := redfishSend(r, "PATCH", *order.SettingsEndpoint, payload)
+	return err
+}
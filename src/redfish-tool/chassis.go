@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	redfish "git.ypbind.de/repository/go-redfish.git"
+)
+
+// ChassisLinks - resources contained in or managing a chassis
+type ChassisLinks struct {
+	ComputerSystems   []OData `json:"ComputerSystems"`
+	ManagedBy         []OData `json:"ManagedBy"`
+	ManagersInChassis []OData `json:"ManagersInChassis"`
+}
+
+// ChassisData - Redfish chassis
+type ChassisData struct {
+	ID                 *string        `json:"Id"`
+	UUID               *string        `json:"UUID"`
+	Name               *string        `json:"Name"`
+	ChassisType        *string        `json:"ChassisType"`
+	Manufacturer       *string        `json:"Manufacturer"`
+	Model              *string        `json:"Model"`
+	SerialNumber       *string        `json:"SerialNumber"`
+	PartNumber         *string        `json:"PartNumber"`
+	AssetTag           *string        `json:"AssetTag"`
+	IndicatorLED       *string        `json:"IndicatorLED"`
+	PowerState         *string        `json:"PowerState"`
+	Status             redfish.Status `json:"Status"`
+	Links              ChassisLinks   `json:"Links"`
+	Thermal            *OData         `json:"Thermal"`
+	ThermalSubsystem   *OData         `json:"ThermalSubsystem"`
+	Power              *OData         `json:"Power"`
+	PowerSubsystem     *OData         `json:"PowerSubsystem"`
+	EnvironmentMetrics *OData         `json:"EnvironmentMetrics"`
+	SelfEndpoint       *string
+}
+
+// fetchAllChassis - get data of all chassis
+func fetchAllChassis(r redfish.Redfish) ([]*ChassisData, error) {
+	var result []*ChassisData
+
+	endpoint, err := serviceEndpoint(r, "Chassis")
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := collectionMembers(r, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, member := range members {
+		var chassis ChassisData
+
+		err = redfishGet(r, member, &chassis)
+		if err != nil {
+			return nil, err
+		}
+
+		ep := member
+		chassis.SelfEndpoint = &ep
+		result = append(result, &chassis)
+	}
+
+	return result, nil
+}
+
+// mapChassisByID - map of all chassis, the chassis ID is used as key
+func mapChassisByID(r redfish.Redfish) (map[string]*ChassisData, error) {
+	var result = make(map[string]*ChassisData)
+
+	clist, err := fetchAllChassis(r)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, chassis := range clist {
+		if chassis.ID == nil {
+			return nil, fmt.Errorf("ERROR: Chassis %s on %s has no Id", *chassis.SelfEndpoint, r.Hostname)
+		}
+		result[*chassis.ID] = chassis
+	}
+
+	return result, nil
+}
+
+// mapChassisByUUID - map of all chassis with an UUID, the UUID is used as key
+func mapChassisByUUID(r redfish.Redfish) (map[string]*ChassisData, error) {
+	var result = make(map[string]*ChassisData)
+
+	clist, err := fetchAllChassis(r)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, chassis := range clist {
+		if chassis.UUID != nil {
+			result[*chassis.UUID] = chassis
+		}
+	}
+
+	return result, nil
+}
+
+// selectChassis - chassis identified by id or all chassis if id is empty, sorted by ID
+func selectChassis(r redfish.Redfish, id string) ([]*ChassisData, error) {
+	var result []*ChassisData
+
+	cmap, err := mapChassisByID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if id != "" {
+		chassis, found := cmap[id]
+		if !found {
+			return nil, fmt.Errorf("ERROR: Chassis %s not found on %s", id, r.Hostname)
+		}
+		return []*ChassisData{chassis}, nil
+	}
+
+	for _, chassis := range cmap {
+		result = append(result, chassis)
+	}
+	sort.Slice(result, func(i int, j int) bool {
+		return lessNatural(*result[i].ID, *result[j].ID)
+	})
+
+	return result, nil
+}
+
+// odataList - endpoints of a list of references
+func odataList(list []OData) []string {
+	var result []string
+
+	for _, o := range list {
+		if o.ID != nil {
+			result = append(result, *o.ID)
+		}
+	}
+
+	return result
+}
+
+// chassisManagers - endpoints of all managers of a chassis
+func chassisManagers(chassis *ChassisData) []string {
+	return append(odataList(chassis.Links.ManagedBy), odataList(chassis.Links.ManagersInChassis)...)
+}
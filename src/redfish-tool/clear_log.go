@@ -0,0 +1,74 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	redfish "git.ypbind.de/repository/go-redfish.git"
+	"io"
+)
+
+func clearLog(r redfish.Redfish, args []string, opts *OutputOptions, out io.Writer) error {
+	var llist = make([]*LogData, 0)
+
+	argParse := flag.NewFlagSet("clear-log", flag.ExitOnError)
+
+	var manager = argParse.String("manager", "", "Clear log service of manager identified by ID")
+	var system = argParse.String("system", "", "Clear log service of system identified by ID")
+	var logID = argParse.String("log", "", "Clear log service identified by ID")
+
+	argParse.Parse(args)
+
+	if *manager != "" && *system != "" {
+		return usageError("ERROR: Options -manager and -system are mutually exclusive")
+	}
+
+	if *logID == "" {
+		return usageError("ERROR: Required option -log not found")
+	}
+
+	// Initialize session
+	err := r.Initialise()
+	if err != nil {
+		return fmt.Errorf("ERROR: Initialisation failed for %s: %s", r.Hostname, err.Error())
+	}
+
+	// Login
+	err = r.Login()
+	if err != nil {
+		return fmt.Errorf("ERROR: Login to %s failed: %s", r.Hostname, err.Error())
+	}
+
+	defer r.Logout()
+
+	sources, err := resourceOwners(r, *manager, *system)
+	if err != nil {
+		return err
+	}
+
+	for _, src := range sources {
+		services, err := fetchLogServices(r, src, *logID, false)
+		if err != nil {
+			return err
+		}
+		llist = append(llist, services...)
+	}
+
+	if len(llist) == 0 {
+		return fmt.Errorf("ERROR: Log service %s not found on %s", *logID, r.Hostname)
+	}
+
+	if len(llist) > 1 {
+		return fmt.Errorf("ERROR: Log service %s found on more than one manager or system of %s, use -manager or -system", *logID, r.Hostname)
+	}
+
+	if llist[0].ClearLog == nil {
+		return fmt.Errorf("ERROR: Log service %s on %s doesn't support LogService.ClearLog", *logID, r.Hostname)
+	}
+
+	if opts.Format == OutputText {
+		fmt.Fprintln(out, r.Hostname)
+	}
+
+	_, err = redfishSend(r, "POST", *llist[0].ClearLog, make(map[string]interface{}))
+	return err
+}
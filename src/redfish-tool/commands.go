@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bytes"
+	redfish "git.ypbind.de/repository/go-redfish.git"
+	log "github.com/sirupsen/logrus"
+	"io"
+	"reflect"
+	"sync"
+	"text/template"
+)
+
+// OutputOptions - output format and its options, passed to all commands
+type OutputOptions struct {
+	Format      uint
+	Template    *template.Template
+	SortField   string
+	SortReverse bool
+	Fields      []string
+	Filters     []FieldFilter
+}
+
+// commandFunc - run a command on a single host, output is written to out
+type commandFunc func(r redfish.Redfish, args []string, opts *OutputOptions, out io.Writer) error
+
+// usageError - invalid or missing options of a command, reported once instead of for every host
+type usageError string
+
+func (e usageError) Error() string {
+	return string(e)
+}
+
+// hostResult - output and error of a command run on a single host
+type hostResult struct {
+	Hostname string
+	Output   bytes.Buffer
+	Err      error
+	done     chan struct{}
+}
+
+// terminalLock - password prompts of commands running in parallel must not overlap
+var terminalLock sync.Mutex
+
+var commands = map[string]commandFunc{
+	"get-all-users":    getAllUsers,
+	"get-user":         getUser,
+	"get-all-roles":    getAllRoles,
+	"get-role":         getRole,
+	"get-all-managers": getAllManagers,
+	"get-manager":      getManager,
+	"get-all-systems":  getAllSystems,
+	"get-system":       getSystem,
+	"get-inventory":    getInventory,
+	"get-storage":      getStorage,
+	"get-bios":         getBios,
+	"set-bios":         setBios,
+	"get-all-chassis":  getAllChassis,
+	"get-chassis":      getChassis,
+	"get-thermal":      getThermal,
+	"get-power":        getPower,
+	"set-power-limit":  setPowerLimit,
+	"gen-csr":          genCSR,
+	"fetch-csr":        fetchCSR,
+	"import-cert":      importCertificate,
+	"reset-sp":         resetSP,
+	"add-user":         addUser,
+	"del-user":         delUser,
+	"modify-user":      modifyUser,
+	"passwd":           passwd,
+	"system-power":     systemPower,
+	"set-boot":         setBoot,
+	"get-boot-order":   getBootOrder,
+	"set-boot-order":   setBootOrder,
+	"get-license":      getLicense,
+	"get-media":        getMedia,
+	"insert-media":     insertMedia,
+	"eject-media":      ejectMedia,
+	"get-firmware":     getFirmware,
+	"update-firmware":  updateFirmware,
+	"get-tasks":        getTasks,
+	"wait-task":        waitTask,
+	"get-logs":         getLogs,
+	"clear-log":        clearLog,
+	"add-license":      addLicense,
+}
+
+// tabularCommands - commands supporting -format=table and -format=csv
+var tabularCommands = map[string]bool{
+	"get-all-users":    true,
+	"get-user":         true,
+	"get-all-roles":    true,
+	"get-role":         true,
+	"get-all-managers": true,
+	"get-manager":      true,
+	"get-all-systems":  true,
+	"get-system":       true,
+	"get-inventory":    true,
+	"get-storage":      true,
+	"get-bios":         true,
+	"get-boot-order":   true,
+	"get-all-chassis":  true,
+	"get-chassis":      true,
+	"get-thermal":      true,
+	"get-power":        true,
+	"get-license":      true,
+	"get-firmware":     true,
+	"get-tasks":        true,
+	"get-logs":         true,
+	"get-media":        true,
+}
+
+// commandPreparers - run once before a command is run on all hosts, e.g. to read input which can only be read once,
+// the returned arguments are passed to the command
+var commandPreparers = map[string]func(args []string) ([]string, error){
+	"insert-media": prepareInsertMedia,
+}
+
+// listingCommands - commands listing Redfish items, supporting -sort, -reverse, -fields and -filter, and the type of the listed items
+var listingCommands = map[string]reflect.Type{
+	"get-all-users":    reflect.TypeOf(redfish.AccountData{}),
+	"get-all-roles":    reflect.TypeOf(redfish.RoleData{}),
+	"get-all-managers": reflect.TypeOf(redfish.ManagerData{}),
+	"get-all-systems":  reflect.TypeOf(redfish.SystemData{}),
+	"get-all-chassis":  reflect.TypeOf(ChassisData{}),
+	"get-firmware":     reflect.TypeOf(FirmwareData{}),
+}
+
+// runOnHosts - run cmd on all hosts, at most parallel hosts at the same time
+// The results are returned in the same order as rfList, the channel done of every result is closed
+// as soon as the command has been finished for this host
+func runOnHosts(rfList []redfish.Redfish, parallel int, cmd commandFunc, args []string, opts *OutputOptions) []*hostResult {
+	results := make([]*hostResult, len(rfList))
+	for i, rf := range rfList {
+		results[i] = &hostResult{
+			Hostname: rf.Hostname,
+			done:     make(chan struct{}),
+		}
+	}
+
+	jobs := make(chan int)
+	for w := 0; w < parallel; w++ {
+		go func() {
+			for i := range jobs {
+				if rfList[i].Verbose {
+					log.WithFields(log.Fields{
+						"hostname": rfList[i].Hostname,
+					}).Info("Connecting to host")
+				}
+
+				results[i].Err = cmd(rfList[i], args, opts, &results[i].Output)
+				close(results[i].done)
+			}
+		}()
+	}
+
+	go func() {
+		for i := range rfList {
+			jobs <- i
+		}
+		close(jobs)
+	}()
+
+	return results
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"gopkg.in/yaml.v2"
+)
+
+// HostConfiguration - connection settings, either global or for a single host
+type HostConfiguration struct {
+	User         *string `yaml:"user"`
+	Password     *string `yaml:"password"`
+	PasswordFile *string `yaml:"password_file"`
+	Port         *int    `yaml:"port"`
+	Timeout      *int64  `yaml:"timeout"`
+	Insecure     *bool   `yaml:"insecure"`
+}
+
+// Configuration - content of the configuration file
+type Configuration struct {
+	Global HostConfiguration            `yaml:"global"`
+	Hosts  map[string]HostConfiguration `yaml:"hosts"`
+}
+
+func readConfigurationFile(f string) (*Configuration, error) {
+	var cfg Configuration
+
+	raw, err := ioutil.ReadFile(f)
+	if err != nil {
+		return nil, err
+	}
+
+	err = yaml.UnmarshalStrict(raw, &cfg)
+	if err != nil {
+		return nil, fmt.Errorf("ERROR: Can't parse configuration file %s: %s", f, err.Error())
+	}
+
+	if cfg.Global.Password != nil && cfg.Global.PasswordFile != nil {
+		return nil, fmt.Errorf("ERROR: password and password_file are mutually exclusive in global section of %s", f)
+	}
+
+	for host, hcfg := range cfg.Hosts {
+		if hcfg.Password != nil && hcfg.PasswordFile != nil {
+			return nil, fmt.Errorf("ERROR: password and password_file are mutually exclusive for host %s in %s", host, f)
+		}
+	}
+
+	return &cfg, nil
+}
+
+// mergeHostConfiguration - settings in o replace the settings in h
+func mergeHostConfiguration(h HostConfiguration, o HostConfiguration) HostConfiguration {
+	if o.User != nil {
+		h.User = o.User
+	}
+
+	// password and password file replace each other
+	if o.Password != nil {
+		h.Password = o.Password
+		h.PasswordFile = nil
+	}
+	if o.PasswordFile != nil {
+		h.PasswordFile = o.PasswordFile
+		h.Password = nil
+	}
+
+	if o.Port != nil {
+		h.Port = o.Port
+	}
+
+	if o.Timeout != nil {
+		h.Timeout = o.Timeout
+	}
+
+	if o.Insecure != nil {
+		h.Insecure = o.Insecure
+	}
+
+	return h
+}
+
+// buildHostConfiguration - settings for a host from the configuration file (global section, then host section)
+// and options set on the command line, command line options take precedence
+func buildHostConfiguration(host string, cfg *Configuration, cmdLine HostConfiguration) HostConfiguration {
+	var result HostConfiguration
+
+	if cfg != nil {
+		result = mergeHostConfiguration(result, cfg.Global)
+		hcfg, found := cfg.Hosts[host]
+		if found {
+			result = mergeHostConfiguration(result, hcfg)
+		}
+	}
+
+	return mergeHostConfiguration(result, cmdLine)
+}
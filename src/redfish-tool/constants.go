@@ -7,4 +7,44 @@ const (
 	OutputText uint = iota
 	// OutputJSON - output as JSON, one item per line
 	OutputJSON
+	// OutputTable - output as aligned table, one item per line
+	OutputTable
+	// OutputCSV - output as CSV with header line, one item per line
+	OutputCSV
+	// OutputJSONArray - output of all hosts as a single JSON array
+	OutputJSONArray
+	// OutputYAML - output as YAML, the output of all hosts is a mapping of host names
+	OutputYAML
+	// OutputTemplate - output rendered by a user-defined template, see text/template
+	OutputTemplate
 )
+
+const (
+	// ExitOK - command succeeded on all hosts
+	ExitOK int = iota
+	// ExitFailure - command failed on all hosts or could not be started at all
+	ExitFailure
+	// ExitUsage - invalid or missing options or commands
+	ExitUsage
+	// ExitPartialFailure - command failed on some, but not all, hosts
+	ExitPartialFailure
+)
+
+const (
+	// HasPowerLimit - vendor supports power capping by PowerLimit of the PowerControl of a chassis
+	HasPowerLimit uint = 1 << iota
+	// HasOemVirtualMedia - vendor provides OEM actions to insert and eject virtual media
+	HasOemVirtualMedia
+)
+
+// vendorCapabilities - capabilities of the vendors not covered by redfish.VendorCapabilities,
+// the key is the vendor flavor reported by GetVendorFlavor
+var vendorCapabilities = map[string]uint{
+	"vanilla":    HasPowerLimit,
+	"hp":         HasOemVirtualMedia,
+	"huawei":     HasPowerLimit,
+	"inspur":     0,
+	"supermicro": HasPowerLimit,
+	"dell":       HasPowerLimit,
+	"lenovo":     HasPowerLimit,
+}
@@ -1,23 +1,25 @@
 package main
 
 import (
-	"errors"
 	"flag"
 	"fmt"
 	redfish "git.ypbind.de/repository/go-redfish.git"
+	"io"
 )
 
-func delUser(r redfish.Redfish, args []string) error {
+func delUser(r redfish.Redfish, args []string, opts *OutputOptions, out io.Writer) error {
 	argParse := flag.NewFlagSet("del-user", flag.ExitOnError)
 
 	var name = argParse.String("name", "", "Name of user account to remove")
 
 	argParse.Parse(args)
 
-	fmt.Println(r.Hostname)
+	if opts.Format == OutputText {
+		fmt.Fprintln(out, r.Hostname)
+	}
 
 	if *name == "" {
-		return errors.New("ERROR: Required options -name not found")
+		return usageError("ERROR: Required options -name not found")
 	}
 
 	// Initialize session
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	redfish "git.ypbind.de/repository/go-redfish.git"
+	"io"
+)
+
+func ejectMedia(r redfish.Redfish, args []string, opts *OutputOptions, out io.Writer) error {
+	argParse := flag.NewFlagSet("eject-media", flag.ExitOnError)
+
+	var manager = argParse.String("manager", "", "Use virtual media of manager identified by ID")
+	var system = argParse.String("system", "", "Use virtual media of system identified by ID")
+	var media = argParse.String("media", "", "Eject image from virtual media identified by ID")
+	var all = argParse.Bool("all", false, "Eject all inserted images")
+
+	argParse.Parse(args)
+
+	if *manager != "" && *system != "" {
+		return usageError("ERROR: Options -manager and -system are mutually exclusive")
+	}
+
+	if *media != "" && *all {
+		return usageError("ERROR: Options -media and -all are mutually exclusive")
+	}
+
+	if *media == "" && !*all {
+		return usageError("ERROR: Required options -media or -all not found")
+	}
+
+	// Initialize session
+	err := r.Initialise()
+	if err != nil {
+		return fmt.Errorf("ERROR: Initialisation failed for %s: %s", r.Hostname, err.Error())
+	}
+
+	// Login
+	err = r.Login()
+	if err != nil {
+		return fmt.Errorf("ERROR: Login to %s failed: %s", r.Hostname, err.Error())
+	}
+
+	defer r.Logout()
+
+	// some vendors provide OEM actions for virtual media
+	err = r.GetVendorFlavor()
+	if err != nil {
+		return err
+	}
+
+	vlist, err := selectVirtualMedia(r, *manager, *system, *media)
+	if err != nil {
+		return err
+	}
+
+	if *media != "" && len(vlist) > 1 {
+		return fmt.Errorf("ERROR: Virtual media %s found on more than one manager or system of %s, use -manager or -system", *media, r.Hostname)
+	}
+
+	if opts.Format == OutputText {
+		fmt.Fprintln(out, r.Hostname)
+	}
+
+	// -all ejects all inserted images
+	for _, vm := range vlist {
+		if *all && !vm.inserted() {
+			continue
+		}
+
+		err = ejectVirtualMedia(r, vm)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -1,12 +1,45 @@
 package main
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	redfish "git.ypbind.de/repository/go-redfish.git"
+	log "github.com/sirupsen/logrus"
+	"io"
 )
 
-func fetchCSR(r redfish.Redfish) error {
+func printCSRJSON(r redfish.Redfish, csr string) string {
+	str, err := json.Marshal(csr)
+	// Should NEVER happen!
+	if err != nil {
+		log.Panic(err)
+	}
+
+	return fmt.Sprintf("{\"%s\":%s}\n", r.Hostname, string(str))
+}
+
+func printCSR(r redfish.Redfish, csr string, opts *OutputOptions) (string, error) {
+	if opts.Format == OutputJSON {
+		return printCSRJSON(r, csr), nil
+	}
+
+	if opts.Format == OutputJSONArray {
+		return formatJSONData(csr), nil
+	}
+
+	if opts.Format == OutputYAML {
+		return formatYAML(r, csr), nil
+	}
+
+	if opts.Format == OutputTemplate {
+		return formatTemplate(r, opts, csr)
+	}
+
+	return r.Hostname + "\n" + csr, nil
+}
+
+func fetchCSR(r redfish.Redfish, args []string, opts *OutputOptions, out io.Writer) error {
 	// Initialize session
 	err := r.Initialise()
 	if err != nil {
@@ -30,7 +63,9 @@ func fetchCSR(r redfish.Redfish) error {
 	capa, found := redfish.VendorCapabilities[r.FlavorString]
 	if found {
 		if capa&redfish.HasSecurityService != redfish.HasSecurityService {
-			fmt.Println(r.Hostname)
+			if opts.Format == OutputText {
+				fmt.Fprintln(out, r.Hostname)
+			}
 			return errors.New("Vendor does not support CSR generation")
 		}
 	}
@@ -40,8 +75,11 @@ func fetchCSR(r redfish.Redfish) error {
 		return err
 	}
 
-	fmt.Println(r.Hostname)
-	fmt.Println(csr)
+	output, err := printCSR(r, csr, opts)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, output)
 
 	return nil
 }
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+
+	redfish "git.ypbind.de/repository/go-redfish.git"
+)
+
+// FirmwareData - firmware component from the firmware inventory of the UpdateService
+type FirmwareData struct {
+	ID           *string        `json:"Id"`
+	Name         *string        `json:"Name"`
+	Version      *string        `json:"Version"`
+	Updateable   *bool          `json:"Updateable"`
+	Manufacturer *string        `json:"Manufacturer"`
+	SoftwareID   *string        `json:"SoftwareId"`
+	ReleaseDate  *string        `json:"ReleaseDate"`
+	Status       redfish.Status `json:"Status"`
+	SelfEndpoint *string
+}
+
+// updateServiceResource - UpdateService of the management board
+type updateServiceResource struct {
+	FirmwareInventory    *OData  `json:"FirmwareInventory"`
+	HTTPPushURI          *string `json:"HttpPushUri"`
+	MultipartHTTPPushURI *string `json:"MultipartHttpPushUri"`
+	Actions              struct {
+		SimpleUpdate *struct {
+			Target *string `json:"target"`
+		} `json:"#UpdateService.SimpleUpdate"`
+	} `json:"Actions"`
+}
+
+// fetchUpdateService - UpdateService of the management board
+func fetchUpdateService(r redfish.Redfish) (*updateServiceResource, error) {
+	var result updateServiceResource
+
+	endpoint, err := serviceEndpoint(r, "UpdateService")
+	if err != nil {
+		return nil, err
+	}
+
+	err = redfishGet(r, endpoint, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// mapFirmwareByID - map of all firmware components, the ID of the component is used as key
+func mapFirmwareByID(r redfish.Redfish) (map[string]*FirmwareData, error) {
+	var result = make(map[string]*FirmwareData)
+
+	update, err := fetchUpdateService(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.FirmwareInventory == nil || update.FirmwareInventory.ID == nil {
+		return nil, fmt.Errorf("ERROR: No firmware inventory provided by %s", r.Hostname)
+	}
+
+	members, err := collectionMembers(r, *update.FirmwareInventory.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, member := range members {
+		var fw FirmwareData
+
+		err = redfishGet(r, member, &fw)
+		if err != nil {
+			return nil, err
+		}
+
+		ep := member
+		fw.SelfEndpoint = &ep
+
+		// some vendors omit the Id, use the endpoint instead
+		key := member
+		if fw.ID != nil {
+			key = *fw.ID
+		}
+		result[key] = &fw
+	}
+
+	return result, nil
+}
@@ -5,6 +5,7 @@ import (
 	"flag"
 	"fmt"
 	redfish "git.ypbind.de/repository/go-redfish.git"
+	"io"
 )
 
 func compareAndSetCSRField(s *string, a *string) *string {
@@ -31,7 +32,7 @@ func compareAndSetCSRField(s *string, a *string) *string {
 	}
 }
 
-func genCSR(r redfish.Redfish, args []string) error {
+func genCSR(r redfish.Redfish, args []string, opts *OutputOptions, out io.Writer) error {
 	var csrdata redfish.CSRData
 
 	argParse := flag.NewFlagSet("gen-csr", flag.ExitOnError)
@@ -60,7 +61,7 @@ func genCSR(r redfish.Redfish, args []string) error {
 
 	// at least the common-name (CN) must be set, see Issue#3
 	if *cn == "" {
-		return fmt.Errorf("ERROR: At least the common name must be set for CSR generation")
+		return usageError("ERROR: At least the common name must be set for CSR generation")
 	}
 
 	// Initialize session
@@ -86,7 +87,9 @@ func genCSR(r redfish.Redfish, args []string) error {
 	capa, found := redfish.VendorCapabilities[r.FlavorString]
 	if found {
 		if capa&redfish.HasSecurityService != redfish.HasSecurityService {
-			fmt.Println(r.Hostname)
+			if opts.Format == OutputText {
+				fmt.Fprintln(out, r.Hostname)
+			}
 			return errors.New("Vendor does not support CSR generation")
 		}
 	}
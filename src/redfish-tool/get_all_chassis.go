@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	redfish "git.ypbind.de/repository/go-redfish.git"
+	log "github.com/sirupsen/logrus"
+	"io"
+)
+
+func printAllChassisText(r redfish.Redfish, cmap map[string]*ChassisData, opts *OutputOptions) string {
+	var result string
+
+	result = r.Hostname + "\n"
+	for _, cname := range selectKeys(cmap, opts) {
+		result += " " + cname + "\n"
+		result += chassisText(cmap[cname], "  ")
+	}
+
+	return result
+}
+
+func printAllChassisJSON(r redfish.Redfish, cmap map[string]*ChassisData, opts *OutputOptions) string {
+	var result string
+
+	for _, cname := range selectKeys(cmap, opts) {
+		str, err := json.Marshal(cmap[cname])
+		// Should NEVER happen!
+		if err != nil {
+			log.Panic(err)
+		}
+
+		result += fmt.Sprintf("{\"%s\":%s}\n", r.Hostname, string(str))
+	}
+
+	return result
+}
+
+func printAllChassisCSV(r redfish.Redfish, cmap map[string]*ChassisData, opts *OutputOptions) string {
+	var rows [][]string
+
+	for _, cname := range selectKeys(cmap, opts) {
+		rows = append(rows, chassisCSVRow(r, cmap[cname]))
+	}
+
+	return formatCSV(chassisCSVHeader, rows)
+}
+
+func printAllChassis(r redfish.Redfish, cmap map[string]*ChassisData, opts *OutputOptions) (string, error) {
+	if len(opts.Fields) > 0 && opts.Format != OutputTemplate {
+		return printSelectedFields(r, cmap, selectKeys(cmap, opts), opts), nil
+	}
+
+	if opts.Format == OutputJSON {
+		return printAllChassisJSON(r, cmap, opts), nil
+	}
+
+	if opts.Format == OutputJSONArray || opts.Format == OutputYAML {
+		var list = make([]*ChassisData, 0, len(cmap))
+		for _, cname := range selectKeys(cmap, opts) {
+			list = append(list, cmap[cname])
+		}
+		if opts.Format == OutputYAML {
+			return formatYAML(r, list), nil
+		}
+		return formatJSONData(list), nil
+	}
+	if opts.Format == OutputTemplate {
+		var list = make([]interface{}, 0, len(cmap))
+		for _, cname := range selectKeys(cmap, opts) {
+			list = append(list, cmap[cname])
+		}
+		return formatTemplate(r, opts, list...)
+	}
+
+	if opts.Format == OutputTable || opts.Format == OutputCSV {
+		return printAllChassisCSV(r, cmap, opts), nil
+	}
+	return printAllChassisText(r, cmap, opts), nil
+}
+
+func getAllChassis(r redfish.Redfish, args []string, opts *OutputOptions, out io.Writer) error {
+	// Initialize session
+	err := r.Initialise()
+	if err != nil {
+		return fmt.Errorf("ERROR: Initialisation failed for %s: %s", r.Hostname, err.Error())
+	}
+
+	// Login
+	err = r.Login()
+	if err != nil {
+		return fmt.Errorf("ERROR: Login to %s failed: %s", r.Hostname, err.Error())
+	}
+
+	defer r.Logout()
+
+	// get all chassis
+	cmap, err := mapChassisByID(r)
+	if err != nil {
+		return err
+	}
+
+	output, err := printAllChassis(r, cmap, opts)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, output)
+
+	return nil
+}
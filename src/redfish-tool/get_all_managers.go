@@ -5,14 +5,16 @@ import (
 	"fmt"
 	redfish "git.ypbind.de/repository/go-redfish.git"
 	log "github.com/sirupsen/logrus"
+	"io"
 )
 
-func printAllManagersText(r redfish.Redfish, mmap map[string]*redfish.ManagerData) string {
+func printAllManagersText(r redfish.Redfish, mmap map[string]*redfish.ManagerData, opts *OutputOptions) string {
 	var result string
 
-	fmt.Println(r.Hostname)
+	result = r.Hostname + "\n"
 	// loop over all endpoints
-	for mname, mgr := range mmap {
+	for _, mname := range selectKeys(mmap, opts) {
+		mgr := mmap[mname]
 		result += " " + mname + "\n"
 		if mgr.ID != nil {
 			result += "  Id: " + *mgr.ID + "\n"
@@ -49,10 +51,11 @@ func printAllManagersText(r redfish.Redfish, mmap map[string]*redfish.ManagerDat
 	return result
 }
 
-func printAllManagersJSON(r redfish.Redfish, mmap map[string]*redfish.ManagerData) string {
+func printAllManagersJSON(r redfish.Redfish, mmap map[string]*redfish.ManagerData, opts *OutputOptions) string {
 	var result string
 
-	for _, mgr := range mmap {
+	for _, mname := range selectKeys(mmap, opts) {
+		mgr := mmap[mname]
 		str, err := json.Marshal(mgr)
 		// Should NEVER happen!
 		if err != nil {
@@ -65,15 +68,52 @@ func printAllManagersJSON(r redfish.Redfish, mmap map[string]*redfish.ManagerDat
 	return result
 }
 
-func printAllManagers(r redfish.Redfish, mmap map[string]*redfish.ManagerData, format uint) string {
-	if format == OutputJSON {
-		return printAllManagersJSON(r, mmap)
+func printAllManagersCSV(r redfish.Redfish, mmap map[string]*redfish.ManagerData, opts *OutputOptions) string {
+	var rows [][]string
+
+	for _, mname := range selectKeys(mmap, opts) {
+		rows = append(rows, managerCSVRow(r, mmap[mname]))
 	}
 
-	return printAllManagersText(r, mmap)
+	return formatCSV(managerCSVHeader, rows)
 }
 
-func getAllManagers(r redfish.Redfish, format uint) error {
+func printAllManagers(r redfish.Redfish, mmap map[string]*redfish.ManagerData, opts *OutputOptions) (string, error) {
+	if len(opts.Fields) > 0 && opts.Format != OutputTemplate {
+		return printSelectedFields(r, mmap, selectKeys(mmap, opts), opts), nil
+	}
+
+	if opts.Format == OutputJSON {
+		return printAllManagersJSON(r, mmap, opts), nil
+	}
+
+	if opts.Format == OutputJSONArray || opts.Format == OutputYAML {
+		var list = make([]*redfish.ManagerData, 0, len(mmap))
+		for _, mname := range selectKeys(mmap, opts) {
+			list = append(list, mmap[mname])
+		}
+		if opts.Format == OutputYAML {
+			return formatYAML(r, list), nil
+		}
+		return formatJSONData(list), nil
+	}
+
+	if opts.Format == OutputTemplate {
+		var list = make([]interface{}, 0, len(mmap))
+		for _, mname := range selectKeys(mmap, opts) {
+			list = append(list, mmap[mname])
+		}
+		return formatTemplate(r, opts, list...)
+	}
+
+	if opts.Format == OutputTable || opts.Format == OutputCSV {
+		return printAllManagersCSV(r, mmap, opts), nil
+	}
+
+	return printAllManagersText(r, mmap, opts), nil
+}
+
+func getAllManagers(r redfish.Redfish, args []string, opts *OutputOptions, out io.Writer) error {
 	// Initialize session
 	err := r.Initialise()
 	if err != nil {
@@ -93,7 +133,11 @@ func getAllManagers(r redfish.Redfish, format uint) error {
 		return err
 	}
 
-	fmt.Println(printAllManagers(r, mmap, format))
+	output, err := printAllManagers(r, mmap, opts)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, output)
 
 	return nil
 }
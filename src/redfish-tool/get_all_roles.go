@@ -6,12 +6,14 @@ import (
 	"fmt"
 	redfish "git.ypbind.de/repository/go-redfish.git"
 	log "github.com/sirupsen/logrus"
+	"io"
 )
 
-func printAllRolesJSON(r redfish.Redfish, rmap map[string]*redfish.RoleData) string {
+func printAllRolesJSON(r redfish.Redfish, rmap map[string]*redfish.RoleData, opts *OutputOptions) string {
 	var result string
 
-	for _, rle := range rmap {
+	for _, rid := range selectKeys(rmap, opts) {
+		rle := rmap[rid]
 		str, err := json.Marshal(rle)
 		// Should NEVER happen!
 		if err != nil {
@@ -24,13 +26,14 @@ func printAllRolesJSON(r redfish.Redfish, rmap map[string]*redfish.RoleData) str
 	return result
 }
 
-func printAllRolesText(r redfish.Redfish, rmap map[string]*redfish.RoleData) string {
+func printAllRolesText(r redfish.Redfish, rmap map[string]*redfish.RoleData, opts *OutputOptions) string {
 	var result string
 
 	result = r.Hostname + "\n"
 
 	// loop over all endpoints
-	for rid, rle := range rmap {
+	for _, rid := range selectKeys(rmap, opts) {
+		rle := rmap[rid]
 		result += " " + rid + "\n"
 		if rle.ID != nil && *rle.ID != "" {
 			result += "  Id: " + *rle.ID + "\n"
@@ -62,15 +65,52 @@ func printAllRolesText(r redfish.Redfish, rmap map[string]*redfish.RoleData) str
 	return result
 }
 
-func printAllRoles(r redfish.Redfish, rmap map[string]*redfish.RoleData, format uint) string {
-	if format == OutputJSON {
-		return printAllRolesJSON(r, rmap)
+func printAllRolesCSV(r redfish.Redfish, rmap map[string]*redfish.RoleData, opts *OutputOptions) string {
+	var rows [][]string
+
+	for _, rid := range selectKeys(rmap, opts) {
+		rows = append(rows, roleCSVRow(r, rmap[rid]))
+	}
+
+	return formatCSV(roleCSVHeader, rows)
+}
+
+func printAllRoles(r redfish.Redfish, rmap map[string]*redfish.RoleData, opts *OutputOptions) (string, error) {
+	if len(opts.Fields) > 0 && opts.Format != OutputTemplate {
+		return printSelectedFields(r, rmap, selectKeys(rmap, opts), opts), nil
+	}
+
+	if opts.Format == OutputJSON {
+		return printAllRolesJSON(r, rmap, opts), nil
+	}
+
+	if opts.Format == OutputJSONArray || opts.Format == OutputYAML {
+		var list = make([]*redfish.RoleData, 0, len(rmap))
+		for _, rid := range selectKeys(rmap, opts) {
+			list = append(list, rmap[rid])
+		}
+		if opts.Format == OutputYAML {
+			return formatYAML(r, list), nil
+		}
+		return formatJSONData(list), nil
+	}
+
+	if opts.Format == OutputTemplate {
+		var list = make([]interface{}, 0, len(rmap))
+		for _, rid := range selectKeys(rmap, opts) {
+			list = append(list, rmap[rid])
+		}
+		return formatTemplate(r, opts, list...)
+	}
+
+	if opts.Format == OutputTable || opts.Format == OutputCSV {
+		return printAllRolesCSV(r, rmap, opts), nil
 	}
 
-	return printAllRolesText(r, rmap)
+	return printAllRolesText(r, rmap, opts), nil
 }
 
-func getAllRoles(r redfish.Redfish, format uint) error {
+func getAllRoles(r redfish.Redfish, args []string, opts *OutputOptions, out io.Writer) error {
 	// Initialize session
 	err := r.Initialise()
 	if err != nil {
@@ -94,7 +134,9 @@ func getAllRoles(r redfish.Redfish, format uint) error {
 	capa, found := redfish.VendorCapabilities[r.FlavorString]
 	if found {
 		if capa&redfish.HasAccountRoles != redfish.HasAccountRoles {
-			fmt.Println(r.Hostname)
+			if opts.Format == OutputText {
+				fmt.Fprintln(out, r.Hostname)
+			}
 			return errors.New("Vendor does not support roles")
 		}
 	}
@@ -105,7 +147,11 @@ func getAllRoles(r redfish.Redfish, format uint) error {
 		return err
 	}
 
-	fmt.Println(printAllRoles(r, rmap, format))
+	output, err := printAllRoles(r, rmap, opts)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, output)
 
 	return nil
 }
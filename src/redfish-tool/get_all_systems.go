@@ -5,13 +5,15 @@ import (
 	"fmt"
 	redfish "git.ypbind.de/repository/go-redfish.git"
 	log "github.com/sirupsen/logrus"
+	"io"
 )
 
-func printAllSystemsText(r redfish.Redfish, smap map[string]*redfish.SystemData) string {
+func printAllSystemsText(r redfish.Redfish, smap map[string]*redfish.SystemData, opts *OutputOptions) string {
 	var result string
 
 	result = r.Hostname + "\n"
-	for sname, sdata := range smap {
+	for _, sname := range selectKeys(smap, opts) {
+		sdata := smap[sname]
 		result += " " + sname + "\n"
 
 		if sdata.ID != nil {
@@ -65,10 +67,11 @@ func printAllSystemsText(r redfish.Redfish, smap map[string]*redfish.SystemData)
 	return result
 }
 
-func printAllSystemsJSON(r redfish.Redfish, smap map[string]*redfish.SystemData) string {
+func printAllSystemsJSON(r redfish.Redfish, smap map[string]*redfish.SystemData, opts *OutputOptions) string {
 	var result string
 
-	for _, sdata := range smap {
+	for _, sname := range selectKeys(smap, opts) {
+		sdata := smap[sname]
 		str, err := json.Marshal(sdata)
 
 		// Should NEVER happen!
@@ -82,14 +85,50 @@ func printAllSystemsJSON(r redfish.Redfish, smap map[string]*redfish.SystemData)
 	return result
 }
 
-func printAllSystems(r redfish.Redfish, smap map[string]*redfish.SystemData, format uint) string {
-	if format == OutputJSON {
-		return printAllSystemsJSON(r, smap)
+func printAllSystemsCSV(r redfish.Redfish, smap map[string]*redfish.SystemData, opts *OutputOptions) string {
+	var rows [][]string
+
+	for _, sname := range selectKeys(smap, opts) {
+		rows = append(rows, systemCSVRow(r, smap[sname]))
+	}
+
+	return formatCSV(systemCSVHeader, rows)
+}
+
+func printAllSystems(r redfish.Redfish, smap map[string]*redfish.SystemData, opts *OutputOptions) (string, error) {
+	if len(opts.Fields) > 0 && opts.Format != OutputTemplate {
+		return printSelectedFields(r, smap, selectKeys(smap, opts), opts), nil
+	}
+
+	if opts.Format == OutputJSON {
+		return printAllSystemsJSON(r, smap, opts), nil
+	}
+
+	if opts.Format == OutputJSONArray || opts.Format == OutputYAML {
+		var list = make([]*redfish.SystemData, 0, len(smap))
+		for _, sname := range selectKeys(smap, opts) {
+			list = append(list, smap[sname])
+		}
+		if opts.Format == OutputYAML {
+			return formatYAML(r, list), nil
+		}
+		return formatJSONData(list), nil
 	}
-	return printAllSystemsText(r, smap)
+	if opts.Format == OutputTemplate {
+		var list = make([]interface{}, 0, len(smap))
+		for _, sname := range selectKeys(smap, opts) {
+			list = append(list, smap[sname])
+		}
+		return formatTemplate(r, opts, list...)
+	}
+
+	if opts.Format == OutputTable || opts.Format == OutputCSV {
+		return printAllSystemsCSV(r, smap, opts), nil
+	}
+	return printAllSystemsText(r, smap, opts), nil
 }
 
-func getAllSystems(r redfish.Redfish, format uint) error {
+func getAllSystems(r redfish.Redfish, args []string, opts *OutputOptions, out io.Writer) error {
 	// Initialize session
 	err := r.Initialise()
 	if err != nil {
@@ -110,7 +149,11 @@ func getAllSystems(r redfish.Redfish, format uint) error {
 		return err
 	}
 
-	fmt.Println(printAllSystems(r, smap, format))
+	output, err := printAllSystems(r, smap, opts)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, output)
 
 	return nil
 }
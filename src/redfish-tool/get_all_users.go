@@ -5,15 +5,17 @@ import (
 	"fmt"
 	redfish "git.ypbind.de/repository/go-redfish.git"
 	log "github.com/sirupsen/logrus"
+	"io"
 )
 
-func printAllUsersText(r redfish.Redfish, amap map[string]*redfish.AccountData) string {
+func printAllUsersText(r redfish.Redfish, amap map[string]*redfish.AccountData, opts *OutputOptions) string {
 	var result string
 
 	result = r.Hostname + "\n"
 
 	// loop over all endpoints
-	for aname, acc := range amap {
+	for _, aname := range selectKeys(amap, opts) {
+		acc := amap[aname]
 
 		result += " " + aname + "\n"
 
@@ -60,10 +62,11 @@ func printAllUsersText(r redfish.Redfish, amap map[string]*redfish.AccountData)
 	return result
 }
 
-func printAllUsersJSON(r redfish.Redfish, amap map[string]*redfish.AccountData) string {
+func printAllUsersJSON(r redfish.Redfish, amap map[string]*redfish.AccountData, opts *OutputOptions) string {
 	var result string
 
-	for _, acc := range amap {
+	for _, aname := range selectKeys(amap, opts) {
+		acc := amap[aname]
 		str, err := json.Marshal(acc)
 		// Should NEVER happen!
 		if err != nil {
@@ -76,14 +79,50 @@ func printAllUsersJSON(r redfish.Redfish, amap map[string]*redfish.AccountData)
 	return result
 }
 
-func printAllUsers(r redfish.Redfish, amap map[string]*redfish.AccountData, format uint) string {
-	if format == OutputJSON {
-		return printAllUsersJSON(r, amap)
+func printAllUsersCSV(r redfish.Redfish, amap map[string]*redfish.AccountData, opts *OutputOptions) string {
+	var rows [][]string
+
+	for _, aname := range selectKeys(amap, opts) {
+		rows = append(rows, userCSVRow(r, amap[aname]))
+	}
+
+	return formatCSV(userCSVHeader, rows)
+}
+
+func printAllUsers(r redfish.Redfish, amap map[string]*redfish.AccountData, opts *OutputOptions) (string, error) {
+	if len(opts.Fields) > 0 && opts.Format != OutputTemplate {
+		return printSelectedFields(r, amap, selectKeys(amap, opts), opts), nil
+	}
+
+	if opts.Format == OutputJSON {
+		return printAllUsersJSON(r, amap, opts), nil
+	}
+
+	if opts.Format == OutputJSONArray || opts.Format == OutputYAML {
+		var list = make([]*redfish.AccountData, 0, len(amap))
+		for _, aname := range selectKeys(amap, opts) {
+			list = append(list, amap[aname])
+		}
+		if opts.Format == OutputYAML {
+			return formatYAML(r, list), nil
+		}
+		return formatJSONData(list), nil
 	}
-	return printAllUsersText(r, amap)
+	if opts.Format == OutputTemplate {
+		var list = make([]interface{}, 0, len(amap))
+		for _, aname := range selectKeys(amap, opts) {
+			list = append(list, amap[aname])
+		}
+		return formatTemplate(r, opts, list...)
+	}
+
+	if opts.Format == OutputTable || opts.Format == OutputCSV {
+		return printAllUsersCSV(r, amap, opts), nil
+	}
+	return printAllUsersText(r, amap, opts), nil
 }
 
-func getAllUsers(r redfish.Redfish, format uint) error {
+func getAllUsers(r redfish.Redfish, args []string, opts *OutputOptions, out io.Writer) error {
 	// Initialize session
 	err := r.Initialise()
 	if err != nil {
@@ -103,6 +142,10 @@ func getAllUsers(r redfish.Redfish, format uint) error {
 		return err
 	}
 
-	fmt.Println(printAllUsers(r, amap, format))
+	output, err := printAllUsers(r, amap, opts)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, output)
 	return nil
 }
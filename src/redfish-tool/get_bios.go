@@ -0,0 +1,222 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	redfish "git.ypbind.de/repository/go-redfish.git"
+	log "github.com/sirupsen/logrus"
+	"io"
+	"sort"
+	"strings"
+)
+
+// biosAttributeNames - sorted names of current and pending attributes
+func biosAttributeNames(bios *BiosData) []string {
+	var result []string
+
+	for name := range bios.Attributes {
+		result = append(result, name)
+	}
+	for name := range bios.Pending {
+		if _, found := bios.Attributes[name]; !found {
+			result = append(result, name)
+		}
+	}
+
+	sort.Strings(result)
+	return result
+}
+
+// selectBiosAttributes - only keep the attributes in names (if not empty) and, if onlyPending is set, attributes with pending changes
+func selectBiosAttributes(bios *BiosData, names []string, onlyPending bool) {
+	var keep = make(map[string]bool)
+
+	for _, name := range names {
+		keep[name] = true
+	}
+
+	for name := range bios.Attributes {
+		_, pending := bios.Pending[name]
+		if (len(keep) > 0 && !keep[name]) || (onlyPending && !pending) {
+			delete(bios.Attributes, name)
+		}
+	}
+
+	for name := range bios.Pending {
+		if len(keep) > 0 && !keep[name] {
+			delete(bios.Pending, name)
+		}
+	}
+}
+
+func printBiosText(r redfish.Redfish, blist []*BiosData) string {
+	var result string
+
+	result = r.Hostname + "\n"
+	for _, bios := range blist {
+		result += " " + bios.SystemID + "\n"
+
+		if bios.AttributeRegistry != nil {
+			result += "  AttributeRegistry: " + *bios.AttributeRegistry + "\n"
+		}
+
+		result += "  Attributes:\n"
+		for _, name := range biosAttributeNames(bios) {
+			value, found := bios.Attributes[name]
+			if !found {
+				continue
+			}
+			result += "   " + name + ": " + biosValue(value) + "\n"
+		}
+
+		if len(bios.Pending) > 0 {
+			result += "  Pending:\n"
+			for _, name := range biosAttributeNames(bios) {
+				value, found := bios.Pending[name]
+				if !found {
+					continue
+				}
+				result += "   " + name + ": " + biosValue(value) + "\n"
+			}
+		}
+
+		if bios.SelfEndpoint != nil {
+			result += "  SelfEndpoint: " + *bios.SelfEndpoint + "\n"
+		}
+	}
+
+	return result
+}
+
+func printBiosJSON(r redfish.Redfish, blist []*BiosData) string {
+	var result string
+
+	for _, bios := range blist {
+		str, err := json.Marshal(bios)
+		// Should NEVER happen!
+		if err != nil {
+			log.Panic(err)
+		}
+
+		result += fmt.Sprintf("{\"%s\":%s}\n", r.Hostname, string(str))
+	}
+
+	return result
+}
+
+// biosCSVHeader - columns of -format=table and -format=csv for BIOS attributes, one row per attribute
+var biosCSVHeader = []string{"Hostname", "System", "Attribute", "Value", "Pending"}
+
+func printBiosCSV(r redfish.Redfish, blist []*BiosData) string {
+	var rows [][]string
+
+	for _, bios := range blist {
+		for _, name := range biosAttributeNames(bios) {
+			var pending string
+
+			if value, found := bios.Pending[name]; found {
+				pending = biosValue(value)
+			}
+
+			rows = append(rows, []string{
+				r.Hostname,
+				bios.SystemID,
+				name,
+				biosValue(bios.Attributes[name]),
+				pending,
+			})
+		}
+	}
+
+	return formatCSV(biosCSVHeader, rows)
+}
+
+func printBios(r redfish.Redfish, blist []*BiosData, opts *OutputOptions) (string, error) {
+	if opts.Format == OutputJSON {
+		return printBiosJSON(r, blist), nil
+	}
+
+	if opts.Format == OutputJSONArray {
+		return formatJSONData(blist), nil
+	}
+
+	if opts.Format == OutputYAML {
+		return formatYAML(r, blist), nil
+	}
+
+	if opts.Format == OutputTemplate {
+		var list = make([]interface{}, 0, len(blist))
+		for _, bios := range blist {
+			list = append(list, bios)
+		}
+		return formatTemplate(r, opts, list...)
+	}
+
+	if opts.Format == OutputTable || opts.Format == OutputCSV {
+		return printBiosCSV(r, blist), nil
+	}
+
+	return printBiosText(r, blist), nil
+}
+
+func getBios(r redfish.Redfish, args []string, opts *OutputOptions, out io.Writer) error {
+	var blist = make([]*BiosData, 0)
+	var names []string
+
+	argParse := flag.NewFlagSet("get-bios", flag.ExitOnError)
+
+	var uuid = argParse.String("uuid", "", "Get BIOS attributes of system identified by UUID")
+	var id = argParse.String("id", "", "Get BIOS attributes of system identified by ID")
+	var attributes = argParse.String("attributes", "", "Comma separated list of attributes to show")
+	var pending = argParse.Bool("pending", false, "Only show attributes with pending changes")
+
+	argParse.Parse(args)
+
+	if *uuid != "" && *id != "" {
+		return usageError("ERROR: Options -uuid and -id are mutually exclusive")
+	}
+
+	for _, name := range strings.Split(*attributes, ",") {
+		name = strings.TrimSpace(name)
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+
+	// Initialize session
+	err := r.Initialise()
+	if err != nil {
+		return fmt.Errorf("ERROR: Initialisation failed for %s: %s", r.Hostname, err.Error())
+	}
+
+	// Login
+	err = r.Login()
+	if err != nil {
+		return fmt.Errorf("ERROR: Login to %s failed: %s", r.Hostname, err.Error())
+	}
+
+	defer r.Logout()
+
+	systems, err := selectSystems(r, *id, *uuid)
+	if err != nil {
+		return err
+	}
+
+	for _, sys := range systems {
+		bios, err := fetchBios(r, sys)
+		if err != nil {
+			return err
+		}
+		selectBiosAttributes(bios, names, *pending)
+		blist = append(blist, bios)
+	}
+
+	output, err := printBios(r, blist, opts)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, output)
+
+	return nil
+}
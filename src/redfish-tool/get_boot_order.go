@@ -0,0 +1,168 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	redfish "git.ypbind.de/repository/go-redfish.git"
+	log "github.com/sirupsen/logrus"
+	"io"
+	"strconv"
+)
+
+func printBootOrderText(r redfish.Redfish, blist []*BootOrderData) string {
+	var result string
+
+	result = r.Hostname + "\n"
+	for _, order := range blist {
+		result += " " + order.SystemID + "\n"
+
+		for i, opt := range order.Options {
+			result += "  " + strconv.Itoa(i+1) + ": " + csvString(opt.BootOptionReference) + "\n"
+
+			if opt.DisplayName != nil {
+				result += "   DisplayName: " + *opt.DisplayName + "\n"
+			}
+			if opt.Alias != nil {
+				result += "   Alias: " + *opt.Alias + "\n"
+			}
+			if opt.BootOptionEnabled != nil {
+				result += "   Enabled: " + csvBool(opt.BootOptionEnabled) + "\n"
+			}
+			if opt.UefiDevicePath != nil {
+				result += "   UefiDevicePath: " + *opt.UefiDevicePath + "\n"
+			}
+		}
+
+		if len(order.Available) > 0 {
+			result += "  Not in boot order:\n"
+			for _, opt := range order.Available {
+				result += "   " + csvString(opt.BootOptionReference)
+				if opt.DisplayName != nil {
+					result += " (" + *opt.DisplayName + ")"
+				}
+				result += "\n"
+			}
+		}
+	}
+
+	return result
+}
+
+func printBootOrderJSON(r redfish.Redfish, blist []*BootOrderData) string {
+	var result string
+
+	for _, order := range blist {
+		str, err := json.Marshal(order)
+		// Should NEVER happen!
+		if err != nil {
+			log.Panic(err)
+		}
+
+		result += fmt.Sprintf("{\"%s\":%s}\n", r.Hostname, string(str))
+	}
+
+	return result
+}
+
+// bootOrderCSVHeader - columns of -format=table and -format=csv for the boot order, one row per entry
+var bootOrderCSVHeader = []string{"Hostname", "System", "Position", "BootOptionReference", "DisplayName", "Alias", "Enabled", "UefiDevicePath"}
+
+func printBootOrderCSV(r redfish.Redfish, blist []*BootOrderData) string {
+	var rows [][]string
+
+	for _, order := range blist {
+		for i, opt := range order.Options {
+			rows = append(rows, []string{
+				r.Hostname,
+				order.SystemID,
+				strconv.Itoa(i + 1),
+				csvString(opt.BootOptionReference),
+				csvString(opt.DisplayName),
+				csvString(opt.Alias),
+				csvBool(opt.BootOptionEnabled),
+				csvString(opt.UefiDevicePath),
+			})
+		}
+	}
+
+	return formatCSV(bootOrderCSVHeader, rows)
+}
+
+func printBootOrder(r redfish.Redfish, blist []*BootOrderData, opts *OutputOptions) (string, error) {
+	if opts.Format == OutputJSON {
+		return printBootOrderJSON(r, blist), nil
+	}
+
+	if opts.Format == OutputJSONArray {
+		return formatJSONData(blist), nil
+	}
+
+	if opts.Format == OutputYAML {
+		return formatYAML(r, blist), nil
+	}
+
+	if opts.Format == OutputTemplate {
+		var list = make([]interface{}, 0, len(blist))
+		for _, order := range blist {
+			list = append(list, order)
+		}
+		return formatTemplate(r, opts, list...)
+	}
+
+	if opts.Format == OutputTable || opts.Format == OutputCSV {
+		return printBootOrderCSV(r, blist), nil
+	}
+
+	return printBootOrderText(r, blist), nil
+}
+
+func getBootOrder(r redfish.Redfish, args []string, opts *OutputOptions, out io.Writer) error {
+	var blist = make([]*BootOrderData, 0)
+
+	argParse := flag.NewFlagSet("get-boot-order", flag.ExitOnError)
+
+	var uuid = argParse.String("uuid", "", "Get boot order of system identified by UUID")
+	var id = argParse.String("id", "", "Get boot order of system identified by ID")
+
+	argParse.Parse(args)
+
+	if *uuid != "" && *id != "" {
+		return usageError("ERROR: Options -uuid and -id are mutually exclusive")
+	}
+
+	// Initialize session
+	err := r.Initialise()
+	if err != nil {
+		return fmt.Errorf("ERROR: Initialisation failed for %s: %s", r.Hostname, err.Error())
+	}
+
+	// Login
+	err = r.Login()
+	if err != nil {
+		return fmt.Errorf("ERROR: Login to %s failed: %s", r.Hostname, err.Error())
+	}
+
+	defer r.Logout()
+
+	systems, err := selectSystems(r, *id, *uuid)
+	if err != nil {
+		return err
+	}
+
+	for _, sys := range systems {
+		order, err := fetchBootOrder(r, sys)
+		if err != nil {
+			return err
+		}
+		blist = append(blist, order)
+	}
+
+	output, err := printBootOrder(r, blist, opts)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, output)
+
+	return nil
+}
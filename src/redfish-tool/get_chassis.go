@@ -0,0 +1,230 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	redfish "git.ypbind.de/repository/go-redfish.git"
+	log "github.com/sirupsen/logrus"
+	"io"
+	"strings"
+)
+
+func printChassisJSON(r redfish.Redfish, chassis *ChassisData) string {
+	var result string
+
+	str, err := json.Marshal(chassis)
+	// Should NEVER happen!
+	if err != nil {
+		log.Panic(err)
+	}
+
+	result += fmt.Sprintf("{\"%s\":%s}\n", r.Hostname, string(str))
+
+	return result
+}
+
+// chassisText - chassis attributes as text, every line is prefixed by indent
+func chassisText(chassis *ChassisData, indent string) string {
+	var result string
+
+	if chassis.ID != nil {
+		result += indent + "Id: " + *chassis.ID + "\n"
+	}
+
+	if chassis.UUID != nil {
+		result += indent + "UUID: " + *chassis.UUID + "\n"
+	}
+
+	if chassis.Name != nil {
+		result += indent + "Name: " + *chassis.Name + "\n"
+	}
+
+	if chassis.ChassisType != nil {
+		result += indent + "ChassisType: " + *chassis.ChassisType + "\n"
+	}
+
+	if chassis.Manufacturer != nil {
+		result += indent + "Manufacturer: " + *chassis.Manufacturer + "\n"
+	}
+
+	if chassis.Model != nil {
+		result += indent + "Model: " + *chassis.Model + "\n"
+	}
+
+	if chassis.SerialNumber != nil {
+		result += indent + "SerialNumber: " + *chassis.SerialNumber + "\n"
+	}
+
+	if chassis.PartNumber != nil {
+		result += indent + "PartNumber: " + *chassis.PartNumber + "\n"
+	}
+
+	if chassis.AssetTag != nil {
+		result += indent + "AssetTag: " + *chassis.AssetTag + "\n"
+	}
+
+	if chassis.IndicatorLED != nil {
+		result += indent + "IndicatorLED: " + *chassis.IndicatorLED + "\n"
+	}
+
+	if chassis.PowerState != nil {
+		result += indent + "PowerState: " + *chassis.PowerState + "\n"
+	}
+
+	result += indent + "Status:" + "\n"
+	if chassis.Status.State != nil {
+		result += indent + " State: " + *chassis.Status.State + "\n"
+	}
+	if chassis.Status.Health != nil {
+		result += indent + " Health: " + *chassis.Status.Health + "\n"
+	}
+	if chassis.Status.HealthRollUp != nil {
+		result += indent + " HealthRollUp: " + *chassis.Status.HealthRollUp + "\n"
+	}
+
+	systems := odataList(chassis.Links.ComputerSystems)
+	if len(systems) > 0 {
+		result += indent + "Systems:\n"
+		for _, s := range systems {
+			result += indent + " " + s + "\n"
+		}
+	}
+
+	managers := chassisManagers(chassis)
+	if len(managers) > 0 {
+		result += indent + "Managers:\n"
+		for _, m := range managers {
+			result += indent + " " + m + "\n"
+		}
+	}
+
+	if chassis.SelfEndpoint != nil {
+		result += indent + "SelfEndpoint: " + *chassis.SelfEndpoint + "\n"
+	}
+
+	return result
+}
+
+func printChassisText(r redfish.Redfish, chassis *ChassisData) string {
+	return r.Hostname + "\n" + chassisText(chassis, " ")
+}
+
+// chassisCSVHeader - columns of -format=table and -format=csv for chassis
+var chassisCSVHeader = []string{"Hostname", "Id", "UUID", "Name", "ChassisType", "Manufacturer", "Model", "SerialNumber", "PartNumber", "AssetTag", "IndicatorLED", "PowerState", "State", "Health", "Systems", "Managers", "SelfEndpoint"}
+
+func chassisCSVRow(r redfish.Redfish, chassis *ChassisData) []string {
+	return []string{
+		r.Hostname,
+		csvString(chassis.ID),
+		csvString(chassis.UUID),
+		csvString(chassis.Name),
+		csvString(chassis.ChassisType),
+		csvString(chassis.Manufacturer),
+		csvString(chassis.Model),
+		csvString(chassis.SerialNumber),
+		csvString(chassis.PartNumber),
+		csvString(chassis.AssetTag),
+		csvString(chassis.IndicatorLED),
+		csvString(chassis.PowerState),
+		csvString(chassis.Status.State),
+		csvString(chassis.Status.Health),
+		strings.Join(odataList(chassis.Links.ComputerSystems), " "),
+		strings.Join(chassisManagers(chassis), " "),
+		csvString(chassis.SelfEndpoint),
+	}
+}
+
+func printChassisCSV(r redfish.Redfish, chassis *ChassisData) string {
+	return formatCSV(chassisCSVHeader, [][]string{chassisCSVRow(r, chassis)})
+}
+
+func printChassis(r redfish.Redfish, chassis *ChassisData, opts *OutputOptions) (string, error) {
+	if opts.Format == OutputJSON {
+		return printChassisJSON(r, chassis), nil
+	}
+
+	if opts.Format == OutputJSONArray {
+		return formatJSONData(chassis), nil
+	}
+
+	if opts.Format == OutputYAML {
+		return formatYAML(r, chassis), nil
+	}
+
+	if opts.Format == OutputTemplate {
+		return formatTemplate(r, opts, chassis)
+	}
+
+	if opts.Format == OutputTable || opts.Format == OutputCSV {
+		return printChassisCSV(r, chassis), nil
+	}
+
+	return printChassisText(r, chassis), nil
+}
+
+func getChassis(r redfish.Redfish, args []string, opts *OutputOptions, out io.Writer) error {
+	var chassis *ChassisData
+	var found bool
+	var cmap map[string]*ChassisData
+
+	argParse := flag.NewFlagSet("get-chassis", flag.ExitOnError)
+
+	var uuid = argParse.String("uuid", "", "Get detailed information for chassis identified by UUID")
+	var id = argParse.String("id", "", "Get detailed information for chassis identified by ID")
+
+	argParse.Parse(args)
+
+	if *uuid != "" && *id != "" {
+		return usageError("ERROR: Options -uuid and -id are mutually exclusive")
+	}
+
+	if *uuid == "" && *id == "" {
+		return usageError("ERROR: Required options -uuid or -id not found")
+	}
+
+	// Initialize session
+	err := r.Initialise()
+	if err != nil {
+		return fmt.Errorf("ERROR: Initialisation failed for %s: %s", r.Hostname, err.Error())
+	}
+
+	// Login
+	err = r.Login()
+	if err != nil {
+		return fmt.Errorf("ERROR: Login to %s failed: %s", r.Hostname, err.Error())
+	}
+
+	defer r.Logout()
+
+	// get all chassis
+	if *id != "" {
+		cmap, err = mapChassisByID(r)
+	} else {
+		cmap, err = mapChassisByUUID(r)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if *id != "" {
+		chassis, found = cmap[*id]
+	} else {
+		chassis, found = cmap[*uuid]
+	}
+
+	if found {
+		output, err := printChassis(r, chassis, opts)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, output)
+	} else {
+		if *id != "" {
+			return fmt.Errorf("ERROR: Chassis %s not found on %s", *id, r.Hostname)
+		}
+		return fmt.Errorf("ERROR: Chassis %s not found on %s", *uuid, r.Hostname)
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	redfish "git.ypbind.de/repository/go-redfish.git"
+	log "github.com/sirupsen/logrus"
+	"io"
+)
+
+func printFirmwareText(r redfish.Redfish, fmap map[string]*FirmwareData, opts *OutputOptions) string {
+	var result string
+
+	result = r.Hostname + "\n"
+	for _, fname := range selectKeys(fmap, opts) {
+		fw := fmap[fname]
+		result += " " + fname + "\n"
+
+		if fw.Name != nil {
+			result += "  Name: " + *fw.Name + "\n"
+		}
+
+		if fw.Version != nil {
+			result += "  Version: " + *fw.Version + "\n"
+		}
+
+		if fw.Updateable != nil {
+			result += "  Updateable: " + csvBool(fw.Updateable) + "\n"
+		}
+
+		if fw.Manufacturer != nil {
+			result += "  Manufacturer: " + *fw.Manufacturer + "\n"
+		}
+
+		if fw.ReleaseDate != nil {
+			result += "  ReleaseDate: " + *fw.ReleaseDate + "\n"
+		}
+
+		result += "  Status:" + "\n"
+		if fw.Status.State != nil {
+			result += "   State: " + *fw.Status.State + "\n"
+		}
+		if fw.Status.Health != nil {
+			result += "   Health: " + *fw.Status.Health + "\n"
+		}
+
+		if fw.SelfEndpoint != nil {
+			result += "  SelfEndpoint: " + *fw.SelfEndpoint + "\n"
+		}
+	}
+
+	return result
+}
+
+func printFirmwareJSON(r redfish.Redfish, fmap map[string]*FirmwareData, opts *OutputOptions) string {
+	var result string
+
+	for _, fname := range selectKeys(fmap, opts) {
+		str, err := json.Marshal(fmap[fname])
+		// Should NEVER happen!
+		if err != nil {
+			log.Panic(err)
+		}
+
+		result += fmt.Sprintf("{\"%s\":%s}\n", r.Hostname, string(str))
+	}
+
+	return result
+}
+
+// firmwareCSVHeader - columns of -format=table and -format=csv for firmware components
+var firmwareCSVHeader = []string{"Hostname", "Id", "Name", "Version", "Updateable", "Manufacturer", "ReleaseDate", "State", "Health", "SelfEndpoint"}
+
+func printFirmwareCSV(r redfish.Redfish, fmap map[string]*FirmwareData, opts *OutputOptions) string {
+	var rows [][]string
+
+	for _, fname := range selectKeys(fmap, opts) {
+		fw := fmap[fname]
+		rows = append(rows, []string{
+			r.Hostname,
+			fname,
+			csvString(fw.Name),
+			csvString(fw.Version),
+			csvBool(fw.Updateable),
+			csvString(fw.Manufacturer),
+			csvString(fw.ReleaseDate),
+			csvString(fw.Status.State),
+			csvString(fw.Status.Health),
+			csvString(fw.SelfEndpoint),
+		})
+	}
+
+	return formatCSV(firmwareCSVHeader, rows)
+}
+
+func printFirmware(r redfish.Redfish, fmap map[string]*FirmwareData, opts *OutputOptions) (string, error) {
+	if len(opts.Fields) > 0 && opts.Format != OutputTemplate {
+		return printSelectedFields(r, fmap, selectKeys(fmap, opts), opts), nil
+	}
+
+	if opts.Format == OutputJSON {
+		return printFirmwareJSON(r, fmap, opts), nil
+	}
+
+	if opts.Format == OutputJSONArray || opts.Format == OutputYAML {
+		var list = make([]*FirmwareData, 0, len(fmap))
+		for _, fname := range selectKeys(fmap, opts) {
+			list = append(list, fmap[fname])
+		}
+		if opts.Format == OutputYAML {
+			return formatYAML(r, list), nil
+		}
+		return formatJSONData(list), nil
+	}
+	if opts.Format == OutputTemplate {
+		var list = make([]interface{}, 0, len(fmap))
+		for _, fname := range selectKeys(fmap, opts) {
+			list = append(list, fmap[fname])
+		}
+		return formatTemplate(r, opts, list...)
+	}
+
+	if opts.Format == OutputTable || opts.Format == OutputCSV {
+		return printFirmwareCSV(r, fmap, opts), nil
+	}
+	return printFirmwareText(r, fmap, opts), nil
+}
+
+func getFirmware(r redfish.Redfish, args []string, opts *OutputOptions, out io.Writer) error {
+	// Initialize session
+	err := r.Initialise()
+	if err != nil {
+		return fmt.Errorf("ERROR: Initialisation failed for %s: %s", r.Hostname, err.Error())
+	}
+
+	// Login
+	err = r.Login()
+	if err != nil {
+		return fmt.Errorf("ERROR: Login to %s failed: %s", r.Hostname, err.Error())
+	}
+
+	defer r.Logout()
+
+	// get firmware inventory
+	fmap, err := mapFirmwareByID(r)
+	if err != nil {
+		return err
+	}
+
+	output, err := printFirmware(r, fmap, opts)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, output)
+
+	return nil
+}
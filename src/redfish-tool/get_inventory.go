@@ -0,0 +1,232 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	redfish "git.ypbind.de/repository/go-redfish.git"
+	log "github.com/sirupsen/logrus"
+	"io"
+)
+
+// inventoryItemText - attributes of an inventory item as text, every line is prefixed by indent
+func inventoryItemText(item InventoryItem, indent string) string {
+	var result string
+
+	if item.ID != nil {
+		result += indent + *item.ID + "\n"
+	} else {
+		result += indent + "-\n"
+	}
+
+	if item.Name != nil {
+		result += indent + " Name: " + *item.Name + "\n"
+	}
+	if item.Manufacturer != nil {
+		result += indent + " Manufacturer: " + *item.Manufacturer + "\n"
+	}
+	if item.Model != nil {
+		result += indent + " Model: " + *item.Model + "\n"
+	}
+	if item.Slot != nil {
+		result += indent + " Slot: " + *item.Slot + "\n"
+	}
+	if item.Cores != nil {
+		result += indent + " Cores: " + csvFloat(item.Cores) + "\n"
+	}
+	if item.Threads != nil {
+		result += indent + " Threads: " + csvFloat(item.Threads) + "\n"
+	}
+	if item.SpeedMHz != nil {
+		result += indent + " SpeedMHz: " + csvFloat(item.SpeedMHz) + "\n"
+	}
+	if item.CapacityMiB != nil {
+		result += indent + " CapacityMiB: " + csvFloat(item.CapacityMiB) + "\n"
+	}
+	if item.FirmwareVersion != nil {
+		result += indent + " FirmwareVersion: " + *item.FirmwareVersion + "\n"
+	}
+	if item.PartNumber != nil {
+		result += indent + " PartNumber: " + *item.PartNumber + "\n"
+	}
+	if item.SerialNumber != nil {
+		result += indent + " SerialNumber: " + *item.SerialNumber + "\n"
+	}
+	if item.Status.State != nil {
+		result += indent + " State: " + *item.Status.State + "\n"
+	}
+	if item.Status.Health != nil {
+		result += indent + " Health: " + *item.Status.Health + "\n"
+	}
+
+	return result
+}
+
+func printInventoryText(r redfish.Redfish, ilist []*InventoryData) string {
+	var result string
+
+	result = r.Hostname + "\n"
+	for _, inv := range ilist {
+		result += " " + inv.SystemID + "\n"
+
+		result += "  Processors:\n"
+		for _, item := range inv.Processors {
+			result += inventoryItemText(item, "   ")
+		}
+
+		result += "  Memory:\n"
+		for _, item := range inv.Memory {
+			result += inventoryItemText(item, "   ")
+		}
+
+		result += "  PCIeDevices:\n"
+		for _, item := range inv.PCIeDevices {
+			result += inventoryItemText(item, "   ")
+		}
+
+		result += "  NetworkAdapters:\n"
+		for _, item := range inv.NetworkAdapters {
+			result += inventoryItemText(item, "   ")
+		}
+	}
+
+	return result
+}
+
+func printInventoryJSON(r redfish.Redfish, ilist []*InventoryData) string {
+	var result string
+
+	for _, inv := range ilist {
+		str, err := json.Marshal(inv)
+		// Should NEVER happen!
+		if err != nil {
+			log.Panic(err)
+		}
+
+		result += fmt.Sprintf("{\"%s\":%s}\n", r.Hostname, string(str))
+	}
+
+	return result
+}
+
+// inventoryCSVHeader - columns of -format=table and -format=csv for the inventory, one row per item
+var inventoryCSVHeader = []string{"Hostname", "System", "Type", "Id", "Name", "Manufacturer", "Model", "Slot", "Cores", "Threads", "SpeedMHz", "CapacityMiB", "FirmwareVersion", "PartNumber", "SerialNumber", "State", "Health"}
+
+func inventoryCSVRows(r redfish.Redfish, system string, itype string, items []InventoryItem) [][]string {
+	var rows [][]string
+
+	for _, item := range items {
+		rows = append(rows, []string{
+			r.Hostname,
+			system,
+			itype,
+			csvString(item.ID),
+			csvString(item.Name),
+			csvString(item.Manufacturer),
+			csvString(item.Model),
+			csvString(item.Slot),
+			csvFloat(item.Cores),
+			csvFloat(item.Threads),
+			csvFloat(item.SpeedMHz),
+			csvFloat(item.CapacityMiB),
+			csvString(item.FirmwareVersion),
+			csvString(item.PartNumber),
+			csvString(item.SerialNumber),
+			csvString(item.Status.State),
+			csvString(item.Status.Health),
+		})
+	}
+
+	return rows
+}
+
+func printInventoryCSV(r redfish.Redfish, ilist []*InventoryData) string {
+	var rows [][]string
+
+	for _, inv := range ilist {
+		rows = append(rows, inventoryCSVRows(r, inv.SystemID, "Processor", inv.Processors)...)
+		rows = append(rows, inventoryCSVRows(r, inv.SystemID, "Memory", inv.Memory)...)
+		rows = append(rows, inventoryCSVRows(r, inv.SystemID, "PCIeDevice", inv.PCIeDevices)...)
+		rows = append(rows, inventoryCSVRows(r, inv.SystemID, "NetworkAdapter", inv.NetworkAdapters)...)
+	}
+
+	return formatCSV(inventoryCSVHeader, rows)
+}
+
+func printInventory(r redfish.Redfish, ilist []*InventoryData, opts *OutputOptions) (string, error) {
+	if opts.Format == OutputJSON {
+		return printInventoryJSON(r, ilist), nil
+	}
+
+	if opts.Format == OutputJSONArray {
+		return formatJSONData(ilist), nil
+	}
+
+	if opts.Format == OutputYAML {
+		return formatYAML(r, ilist), nil
+	}
+
+	if opts.Format == OutputTemplate {
+		var list = make([]interface{}, 0, len(ilist))
+		for _, inv := range ilist {
+			list = append(list, inv)
+		}
+		return formatTemplate(r, opts, list...)
+	}
+
+	if opts.Format == OutputTable || opts.Format == OutputCSV {
+		return printInventoryCSV(r, ilist), nil
+	}
+
+	return printInventoryText(r, ilist), nil
+}
+
+func getInventory(r redfish.Redfish, args []string, opts *OutputOptions, out io.Writer) error {
+	var ilist = make([]*InventoryData, 0)
+
+	argParse := flag.NewFlagSet("get-inventory", flag.ExitOnError)
+
+	var uuid = argParse.String("uuid", "", "Get inventory of system identified by UUID")
+	var id = argParse.String("id", "", "Get inventory of system identified by ID")
+
+	argParse.Parse(args)
+
+	if *uuid != "" && *id != "" {
+		return usageError("ERROR: Options -uuid and -id are mutually exclusive")
+	}
+
+	// Initialize session
+	err := r.Initialise()
+	if err != nil {
+		return fmt.Errorf("ERROR: Initialisation failed for %s: %s", r.Hostname, err.Error())
+	}
+
+	// Login
+	err = r.Login()
+	if err != nil {
+		return fmt.Errorf("ERROR: Login to %s failed: %s", r.Hostname, err.Error())
+	}
+
+	defer r.Logout()
+
+	slist, err := selectSystems(r, *id, *uuid)
+	if err != nil {
+		return err
+	}
+
+	for _, sys := range slist {
+		inv, err := fetchInventory(r, sys)
+		if err != nil {
+			return err
+		}
+		ilist = append(ilist, inv)
+	}
+
+	output, err := printInventory(r, ilist, opts)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, output)
+
+	return nil
+}
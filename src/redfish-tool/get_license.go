@@ -7,7 +7,7 @@ import (
 	"fmt"
 	redfish "git.ypbind.de/repository/go-redfish.git"
 	log "github.com/sirupsen/logrus"
-	"os"
+	"io"
 )
 
 func printLicenseJSON(r redfish.Redfish, l *redfish.ManagerLicenseData) string {
@@ -17,7 +17,7 @@ func printLicenseJSON(r redfish.Redfish, l *redfish.ManagerLicenseData) string {
 	if err != nil {
 		log.Panic(err)
 	}
-	result = fmt.Sprintf("{\"%s\":%s}", r.Hostname, string(str))
+	result = fmt.Sprintf("{\"%s\":%s}\n", r.Hostname, string(str))
 
 	return result
 }
@@ -54,15 +54,37 @@ func printLicenseText(r redfish.Redfish, l *redfish.ManagerLicenseData) string {
 	return result
 }
 
-func printLicense(r redfish.Redfish, l *redfish.ManagerLicenseData, format uint) string {
-	if format == OutputJSON {
-		return printLicenseJSON(r, l)
+func printLicenseCSV(r redfish.Redfish, l *redfish.ManagerLicenseData) string {
+	return formatCSV([]string{"Hostname", "Name", "Type", "Expiration", "License"}, [][]string{
+		{r.Hostname, l.Name, l.Type, l.Expiration, l.License},
+	})
+}
+
+func printLicense(r redfish.Redfish, l *redfish.ManagerLicenseData, opts *OutputOptions) (string, error) {
+	if opts.Format == OutputJSON {
+		return printLicenseJSON(r, l), nil
+	}
+
+	if opts.Format == OutputJSONArray {
+		return formatJSONData(l), nil
 	}
 
-	return printLicenseText(r, l)
+	if opts.Format == OutputYAML {
+		return formatYAML(r, l), nil
+	}
+
+	if opts.Format == OutputTemplate {
+		return formatTemplate(r, opts, l)
+	}
+
+	if opts.Format == OutputTable || opts.Format == OutputCSV {
+		return printLicenseCSV(r, l), nil
+	}
+
+	return printLicenseText(r, l), nil
 }
 
-func getLicense(r redfish.Redfish, args []string, format uint) error {
+func getLicense(r redfish.Redfish, args []string, opts *OutputOptions, out io.Writer) error {
 	argParse := flag.NewFlagSet("get-license", flag.ExitOnError)
 	var id = argParse.String("id", "", "Management board identified by ID")
 	var uuid = argParse.String("uuid", "", "Management board identified by UUID")
@@ -72,11 +94,11 @@ func getLicense(r redfish.Redfish, args []string, format uint) error {
 	argParse.Parse(args)
 
 	if *uuid != "" && *id != "" {
-		return errors.New("ERROR: Options -uuid and -id are mutually exclusive")
+		return usageError("ERROR: Options -uuid and -id are mutually exclusive")
 	}
 
 	if *uuid == "" && *id == "" {
-		return errors.New("ERROR: Required options -uuid or -id not found")
+		return usageError("ERROR: Required options -uuid or -id not found")
 	}
 
 	// Initialize session
@@ -101,7 +123,9 @@ func getLicense(r redfish.Redfish, args []string, format uint) error {
 	capa, found := redfish.VendorCapabilities[r.FlavorString]
 	if found {
 		if capa&redfish.HasLicense != redfish.HasLicense {
-			fmt.Println(r.Hostname)
+			if opts.Format == OutputText {
+				fmt.Fprintln(out, r.Hostname)
+			}
 			return errors.New("Vendor does not support license operations")
 		}
 	}
@@ -128,13 +152,17 @@ func getLicense(r redfish.Redfish, args []string, format uint) error {
 			return err
 		}
 
-		fmt.Println(printLicense(r, l, format))
+		output, err := printLicense(r, l, opts)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, output)
 
 	} else {
 		if *id != "" {
-			fmt.Fprintf(os.Stderr, "Manager with ID %s not found on %s\n", *id, r.Hostname)
+			return fmt.Errorf("ERROR: Manager with ID %s not found on %s", *id, r.Hostname)
 		} else if *uuid != "" {
-			fmt.Fprintf(os.Stderr, "Manager with UUID %s not found on %s\n", *uuid, r.Hostname)
+			return fmt.Errorf("ERROR: Manager with UUID %s not found on %s", *uuid, r.Hostname)
 		}
 	}
 
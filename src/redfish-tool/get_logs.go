@@ -0,0 +1,183 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	redfish "git.ypbind.de/repository/go-redfish.git"
+	log "github.com/sirupsen/logrus"
+	"io"
+	"strings"
+	"time"
+)
+
+func printLogsText(r redfish.Redfish, llist []*LogData) string {
+	var result string
+
+	result = r.Hostname + "\n"
+	for _, logData := range llist {
+		result += " " + logData.SourceType + " " + logData.SourceID + " " + csvString(logData.ID) + "\n"
+
+		if logData.Name != nil {
+			result += "  Name: " + *logData.Name + "\n"
+		}
+
+		result += "  Entries:\n"
+		for _, entry := range logData.Entries {
+			result += "   " + csvString(entry.Created) + " " + csvString(entry.Severity)
+			if entry.MessageID != nil {
+				result += " " + *entry.MessageID
+			}
+			result += ": " + strings.TrimSpace(csvString(entry.Message)) + "\n"
+		}
+	}
+
+	return result
+}
+
+func printLogsJSON(r redfish.Redfish, llist []*LogData) string {
+	var result string
+
+	for _, logData := range llist {
+		str, err := json.Marshal(logData)
+		// Should NEVER happen!
+		if err != nil {
+			log.Panic(err)
+		}
+
+		result += fmt.Sprintf("{\"%s\":%s}\n", r.Hostname, string(str))
+	}
+
+	return result
+}
+
+// logCSVHeader - columns of -format=table and -format=csv for log entries, one row per entry
+var logCSVHeader = []string{"Hostname", "SourceType", "SourceId", "Log", "Id", "Created", "Severity", "EntryType", "SensorType", "MessageId", "Message"}
+
+func printLogsCSV(r redfish.Redfish, llist []*LogData) string {
+	var rows [][]string
+
+	for _, logData := range llist {
+		for _, entry := range logData.Entries {
+			rows = append(rows, []string{
+				r.Hostname,
+				logData.SourceType,
+				logData.SourceID,
+				csvString(logData.ID),
+				csvString(entry.ID),
+				csvString(entry.Created),
+				csvString(entry.Severity),
+				csvString(entry.EntryType),
+				csvString(entry.SensorType),
+				csvString(entry.MessageID),
+				strings.TrimSpace(csvString(entry.Message)),
+			})
+		}
+	}
+
+	return formatCSV(logCSVHeader, rows)
+}
+
+func printLogs(r redfish.Redfish, llist []*LogData, opts *OutputOptions) (string, error) {
+	if opts.Format == OutputJSON {
+		return printLogsJSON(r, llist), nil
+	}
+
+	if opts.Format == OutputJSONArray {
+		return formatJSONData(llist), nil
+	}
+
+	if opts.Format == OutputYAML {
+		return formatYAML(r, llist), nil
+	}
+
+	if opts.Format == OutputTemplate {
+		var list = make([]interface{}, 0, len(llist))
+		for _, logData := range llist {
+			list = append(list, logData)
+		}
+		return formatTemplate(r, opts, list...)
+	}
+
+	if opts.Format == OutputTable || opts.Format == OutputCSV {
+		return printLogsCSV(r, llist), nil
+	}
+
+	return printLogsText(r, llist), nil
+}
+
+func getLogs(r redfish.Redfish, args []string, opts *OutputOptions, out io.Writer) error {
+	var llist = make([]*LogData, 0)
+	var since time.Time
+	var err error
+
+	argParse := flag.NewFlagSet("get-logs", flag.ExitOnError)
+
+	var manager = argParse.String("manager", "", "Only read log services of manager identified by ID")
+	var system = argParse.String("system", "", "Only read log services of system identified by ID")
+	var logID = argParse.String("log", "", "Only read log service identified by ID")
+	var severity = argParse.String("severity", "", "Only show entries with at least severity OK, Warning or Critical")
+	var _since = argParse.String("since", "", "Only show entries created at or after this time")
+
+	argParse.Parse(args)
+
+	if *manager != "" && *system != "" {
+		return usageError("ERROR: Options -manager and -system are mutually exclusive")
+	}
+
+	if *severity != "" {
+		if _, found := logSeverity[strings.ToLower(*severity)]; !found {
+			return usageError(fmt.Sprintf("ERROR: Invalid severity %s, supported values are OK, Warning and Critical", *severity))
+		}
+	}
+
+	if *_since != "" {
+		since, err = parseLogTime(*_since)
+		if err != nil {
+			return usageError(err.Error())
+		}
+	}
+
+	// Initialize session
+	err = r.Initialise()
+	if err != nil {
+		return fmt.Errorf("ERROR: Initialisation failed for %s: %s", r.Hostname, err.Error())
+	}
+
+	// Login
+	err = r.Login()
+	if err != nil {
+		return fmt.Errorf("ERROR: Login to %s failed: %s", r.Hostname, err.Error())
+	}
+
+	defer r.Logout()
+
+	sources, err := resourceOwners(r, *manager, *system)
+	if err != nil {
+		return err
+	}
+
+	for _, src := range sources {
+		services, err := fetchLogServices(r, src, *logID, true)
+		if err != nil {
+			return err
+		}
+
+		for _, logData := range services {
+			logData.Entries = filterLogEntries(logData.Entries, *severity, since)
+			llist = append(llist, logData)
+		}
+	}
+
+	if *logID != "" && len(llist) == 0 {
+		return fmt.Errorf("ERROR: Log service %s not found on %s", *logID, r.Hostname)
+	}
+
+	output, err := printLogs(r, llist, opts)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, output)
+
+	return nil
+}
@@ -2,12 +2,11 @@ package main
 
 import (
 	"encoding/json"
-	"errors"
 	"flag"
 	"fmt"
 	redfish "git.ypbind.de/repository/go-redfish.git"
 	log "github.com/sirupsen/logrus"
-	"os"
+	"io"
 )
 
 func printManagerJSON(r redfish.Redfish, mgr *redfish.ManagerData) string {
@@ -17,7 +16,7 @@ func printManagerJSON(r redfish.Redfish, mgr *redfish.ManagerData) string {
 	if err != nil {
 		log.Panic(err)
 	}
-	result = fmt.Sprintf("{\"%s\":%s}", r.Hostname, string(str))
+	result = fmt.Sprintf("{\"%s\":%s}\n", r.Hostname, string(str))
 
 	return result
 }
@@ -61,15 +60,52 @@ func printManagerText(r redfish.Redfish, mgr *redfish.ManagerData) string {
 	return result
 }
 
-func printManager(r redfish.Redfish, mgr *redfish.ManagerData, format uint) string {
-	if format == OutputJSON {
-		return printManagerJSON(r, mgr)
+// managerCSVHeader - columns of -format=table and -format=csv for managers
+var managerCSVHeader = []string{"Hostname", "Id", "Name", "ManagerType", "UUID", "FirmwareVersion", "State", "Health", "Endpoint"}
+
+func managerCSVRow(r redfish.Redfish, mgr *redfish.ManagerData) []string {
+	return []string{
+		r.Hostname,
+		csvString(mgr.ID),
+		csvString(mgr.Name),
+		csvString(mgr.ManagerType),
+		csvString(mgr.UUID),
+		csvString(mgr.FirmwareVersion),
+		csvString(mgr.Status.State),
+		csvString(mgr.Status.Health),
+		csvString(mgr.SelfEndpoint),
+	}
+}
+
+func printManagerCSV(r redfish.Redfish, mgr *redfish.ManagerData) string {
+	return formatCSV(managerCSVHeader, [][]string{managerCSVRow(r, mgr)})
+}
+
+func printManager(r redfish.Redfish, mgr *redfish.ManagerData, opts *OutputOptions) (string, error) {
+	if opts.Format == OutputJSON {
+		return printManagerJSON(r, mgr), nil
+	}
+
+	if opts.Format == OutputJSONArray {
+		return formatJSONData(mgr), nil
 	}
 
-	return printManagerText(r, mgr)
+	if opts.Format == OutputYAML {
+		return formatYAML(r, mgr), nil
+	}
+
+	if opts.Format == OutputTemplate {
+		return formatTemplate(r, opts, mgr)
+	}
+
+	if opts.Format == OutputTable || opts.Format == OutputCSV {
+		return printManagerCSV(r, mgr), nil
+	}
+
+	return printManagerText(r, mgr), nil
 }
 
-func getManager(r redfish.Redfish, args []string, format uint) error {
+func getManager(r redfish.Redfish, args []string, opts *OutputOptions, out io.Writer) error {
 	var mgr *redfish.ManagerData
 	var found bool
 	var mmap map[string]*redfish.ManagerData
@@ -81,11 +117,11 @@ func getManager(r redfish.Redfish, args []string, format uint) error {
 	argParse.Parse(args)
 
 	if *uuid != "" && *id != "" {
-		return errors.New("ERROR: Options -uuid and -id are mutually exclusive")
+		return usageError("ERROR: Options -uuid and -id are mutually exclusive")
 	}
 
 	if *uuid == "" && *id == "" {
-		return errors.New("ERROR: Required options -uuid or -id not found")
+		return usageError("ERROR: Required options -uuid or -id not found")
 	}
 
 	// Initialize session
@@ -120,12 +156,16 @@ func getManager(r redfish.Redfish, args []string, format uint) error {
 	}
 
 	if found {
-		fmt.Println(printManager(r, mgr, format))
+		output, err := printManager(r, mgr, opts)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, output)
 	} else {
 		if *id != "" {
-			fmt.Fprintf(os.Stderr, "User %s not found on %s\n", *id, r.Hostname)
+			return fmt.Errorf("ERROR: Manager %s not found on %s", *id, r.Hostname)
 		} else {
-			fmt.Fprintf(os.Stderr, "User %s not found on %s\n", *uuid, r.Hostname)
+			return fmt.Errorf("ERROR: Manager %s not found on %s", *uuid, r.Hostname)
 		}
 	}
 
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	redfish "git.ypbind.de/repository/go-redfish.git"
+	log "github.com/sirupsen/logrus"
+	"io"
+	"strings"
+)
+
+func printMediaText(r redfish.Redfish, vlist []*VirtualMediaData) string {
+	var result string
+
+	result = r.Hostname + "\n"
+	for _, vm := range vlist {
+		result += " " + vm.SourceType + " " + vm.SourceID + " " + csvString(vm.ID) + "\n"
+
+		if vm.Name != nil {
+			result += "  Name: " + *vm.Name + "\n"
+		}
+		if len(vm.MediaTypes) > 0 {
+			result += "  MediaTypes: " + strings.Join(vm.MediaTypes, ", ") + "\n"
+		}
+		if vm.Inserted != nil {
+			result += "  Inserted: " + csvBool(vm.Inserted) + "\n"
+		}
+		if vm.Image != nil {
+			result += "  Image: " + *vm.Image + "\n"
+		}
+		if vm.ImageName != nil {
+			result += "  ImageName: " + *vm.ImageName + "\n"
+		}
+		if vm.WriteProtected != nil {
+			result += "  WriteProtected: " + csvBool(vm.WriteProtected) + "\n"
+		}
+		if vm.ConnectedVia != nil {
+			result += "  ConnectedVia: " + *vm.ConnectedVia + "\n"
+		}
+		if vm.TransferProtocolType != nil {
+			result += "  TransferProtocolType: " + *vm.TransferProtocolType + "\n"
+		}
+		if vm.SelfEndpoint != nil {
+			result += "  SelfEndpoint: " + *vm.SelfEndpoint + "\n"
+		}
+	}
+
+	return result
+}
+
+func printMediaJSON(r redfish.Redfish, vlist []*VirtualMediaData) string {
+	var result string
+
+	for _, vm := range vlist {
+		str, err := json.Marshal(vm)
+		// Should NEVER happen!
+		if err != nil {
+			log.Panic(err)
+		}
+
+		result += fmt.Sprintf("{\"%s\":%s}\n", r.Hostname, string(str))
+	}
+
+	return result
+}
+
+// mediaCSVHeader - columns of -format=table and -format=csv for virtual media slots
+var mediaCSVHeader = []string{"Hostname", "SourceType", "SourceId", "Id", "Name", "MediaTypes", "Inserted", "Image", "WriteProtected", "ConnectedVia", "SelfEndpoint"}
+
+func printMediaCSV(r redfish.Redfish, vlist []*VirtualMediaData) string {
+	var rows [][]string
+
+	for _, vm := range vlist {
+		rows = append(rows, []string{
+			r.Hostname,
+			vm.SourceType,
+			vm.SourceID,
+			csvString(vm.ID),
+			csvString(vm.Name),
+			strings.Join(vm.MediaTypes, " "),
+			csvBool(vm.Inserted),
+			csvString(vm.Image),
+			csvBool(vm.WriteProtected),
+			csvString(vm.ConnectedVia),
+			csvString(vm.SelfEndpoint),
+		})
+	}
+
+	return formatCSV(mediaCSVHeader, rows)
+}
+
+func printMedia(r redfish.Redfish, vlist []*VirtualMediaData, opts *OutputOptions) (string, error) {
+	if opts.Format == OutputJSON {
+		return printMediaJSON(r, vlist), nil
+	}
+
+	if opts.Format == OutputJSONArray {
+		return formatJSONData(vlist), nil
+	}
+
+	if opts.Format == OutputYAML {
+		return formatYAML(r, vlist), nil
+	}
+
+	if opts.Format == OutputTemplate {
+		var list = make([]interface{}, 0, len(vlist))
+		for _, vm := range vlist {
+			list = append(list, vm)
+		}
+		return formatTemplate(r, opts, list...)
+	}
+
+	if opts.Format == OutputTable || opts.Format == OutputCSV {
+		return printMediaCSV(r, vlist), nil
+	}
+
+	return printMediaText(r, vlist), nil
+}
+
+func getMedia(r redfish.Redfish, args []string, opts *OutputOptions, out io.Writer) error {
+	argParse := flag.NewFlagSet("get-media", flag.ExitOnError)
+
+	var manager = argParse.String("manager", "", "Only list virtual media of manager identified by ID")
+	var system = argParse.String("system", "", "Only list virtual media of system identified by ID")
+	var media = argParse.String("media", "", "Only list virtual media identified by ID")
+
+	argParse.Parse(args)
+
+	if *manager != "" && *system != "" {
+		return usageError("ERROR: Options -manager and -system are mutually exclusive")
+	}
+
+	// Initialize session
+	err := r.Initialise()
+	if err != nil {
+		return fmt.Errorf("ERROR: Initialisation failed for %s: %s", r.Hostname, err.Error())
+	}
+
+	// Login
+	err = r.Login()
+	if err != nil {
+		return fmt.Errorf("ERROR: Login to %s failed: %s", r.Hostname, err.Error())
+	}
+
+	defer r.Logout()
+
+	vlist, err := selectVirtualMedia(r, *manager, *system, *media)
+	if err != nil {
+		return err
+	}
+
+	output, err := printMedia(r, vlist, opts)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, output)
+
+	return nil
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	redfish "git.ypbind.de/repository/go-redfish.git"
+	log "github.com/sirupsen/logrus"
+	"io"
+)
+
+func printPowerText(r redfish.Redfish, plist []*PowerData) string {
+	var result string
+
+	result = r.Hostname + "\n"
+	for _, power := range plist {
+		result += " " + power.ChassisID + "\n"
+
+		result += "  PowerControl:\n"
+		for _, pc := range power.PowerControl {
+			if pc.Name != nil {
+				result += "   " + *pc.Name + "\n"
+			} else {
+				result += "   -\n"
+			}
+			if pc.PowerConsumedWatts != nil {
+				result += "    PowerConsumedWatts: " + csvFloat(pc.PowerConsumedWatts) + "\n"
+			}
+			if pc.PowerCapacityWatts != nil {
+				result += "    PowerCapacityWatts: " + csvFloat(pc.PowerCapacityWatts) + "\n"
+			}
+			if pc.PowerMetrics.IntervalInMin != nil {
+				result += "    IntervalInMin: " + csvFloat(pc.PowerMetrics.IntervalInMin) + "\n"
+			}
+			if pc.PowerMetrics.AverageConsumedWatts != nil {
+				result += "    AverageConsumedWatts: " + csvFloat(pc.PowerMetrics.AverageConsumedWatts) + "\n"
+			}
+			if pc.PowerMetrics.MinConsumedWatts != nil {
+				result += "    MinConsumedWatts: " + csvFloat(pc.PowerMetrics.MinConsumedWatts) + "\n"
+			}
+			if pc.PowerMetrics.MaxConsumedWatts != nil {
+				result += "    MaxConsumedWatts: " + csvFloat(pc.PowerMetrics.MaxConsumedWatts) + "\n"
+			}
+			if pc.PowerLimit.LimitInWatts != nil {
+				result += "    LimitInWatts: " + csvFloat(pc.PowerLimit.LimitInWatts) + "\n"
+			}
+			if pc.PowerLimit.LimitException != nil {
+				result += "    LimitException: " + *pc.PowerLimit.LimitException + "\n"
+			}
+			if pc.Status.Health != nil {
+				result += "    Health: " + *pc.Status.Health + "\n"
+			}
+		}
+
+		result += "  PowerSupplies:\n"
+		for _, psu := range power.PowerSupplies {
+			if psu.Name != nil {
+				result += "   " + *psu.Name + "\n"
+			} else if psu.MemberID != nil {
+				result += "   " + *psu.MemberID + "\n"
+			} else {
+				result += "   -\n"
+			}
+			if psu.Manufacturer != nil {
+				result += "    Manufacturer: " + *psu.Manufacturer + "\n"
+			}
+			if psu.Model != nil {
+				result += "    Model: " + *psu.Model + "\n"
+			}
+			if psu.SerialNumber != nil {
+				result += "    SerialNumber: " + *psu.SerialNumber + "\n"
+			}
+			if psu.FirmwareVersion != nil {
+				result += "    FirmwareVersion: " + *psu.FirmwareVersion + "\n"
+			}
+			if psu.PowerSupplyType != nil {
+				result += "    PowerSupplyType: " + *psu.PowerSupplyType + "\n"
+			}
+			if psu.LineInputVoltage != nil {
+				result += "    LineInputVoltage: " + csvFloat(psu.LineInputVoltage) + "\n"
+			}
+			if psu.PowerCapacityWatts != nil {
+				result += "    PowerCapacityWatts: " + csvFloat(psu.PowerCapacityWatts) + "\n"
+			}
+			if psu.LastPowerOutputWatts != nil {
+				result += "    LastPowerOutputWatts: " + csvFloat(psu.LastPowerOutputWatts) + "\n"
+			}
+			if psu.Status.State != nil {
+				result += "    State: " + *psu.Status.State + "\n"
+			}
+			if psu.Status.Health != nil {
+				result += "    Health: " + *psu.Status.Health + "\n"
+			}
+		}
+
+		if len(power.Redundancy) > 0 {
+			result += "  Redundancy:\n"
+			for _, red := range power.Redundancy {
+				if red.Name != nil {
+					result += "   " + *red.Name + "\n"
+				} else {
+					result += "   -\n"
+				}
+				if red.Mode != nil {
+					result += "    Mode: " + *red.Mode + "\n"
+				}
+				if red.MinNumNeeded != nil {
+					result += "    MinNumNeeded: " + csvFloat(red.MinNumNeeded) + "\n"
+				}
+				if red.Status.State != nil {
+					result += "    State: " + *red.Status.State + "\n"
+				}
+				if red.Status.Health != nil {
+					result += "    Health: " + *red.Status.Health + "\n"
+				}
+			}
+		}
+
+		if power.SelfEndpoint != nil {
+			result += "  SelfEndpoint: " + *power.SelfEndpoint + "\n"
+		}
+	}
+
+	return result
+}
+
+func printPowerJSON(r redfish.Redfish, plist []*PowerData) string {
+	var result string
+
+	for _, power := range plist {
+		str, err := json.Marshal(power)
+		// Should NEVER happen!
+		if err != nil {
+			log.Panic(err)
+		}
+
+		result += fmt.Sprintf("{\"%s\":%s}\n", r.Hostname, string(str))
+	}
+
+	return result
+}
+
+// powerCSVHeader - columns of -format=table and -format=csv for power data,
+// one row per power control, power supply and redundancy group
+var powerCSVHeader = []string{"Hostname", "Chassis", "Type", "Name", "Model", "SerialNumber", "FirmwareVersion", "LineInputVoltage", "PowerCapacityWatts", "PowerConsumedWatts", "AverageConsumedWatts", "MinConsumedWatts", "MaxConsumedWatts", "IntervalInMin", "LimitInWatts", "LimitException", "Mode", "State", "Health"}
+
+func printPowerCSV(r redfish.Redfish, plist []*PowerData) string {
+	var rows [][]string
+
+	for _, power := range plist {
+		for _, pc := range power.PowerControl {
+			rows = append(rows, []string{
+				r.Hostname, power.ChassisID, "PowerControl", csvString(pc.Name), "", "", "", "",
+				csvFloat(pc.PowerCapacityWatts), csvFloat(pc.PowerConsumedWatts),
+				csvFloat(pc.PowerMetrics.AverageConsumedWatts), csvFloat(pc.PowerMetrics.MinConsumedWatts),
+				csvFloat(pc.PowerMetrics.MaxConsumedWatts), csvFloat(pc.PowerMetrics.IntervalInMin),
+				csvFloat(pc.PowerLimit.LimitInWatts), csvString(pc.PowerLimit.LimitException), "",
+				csvString(pc.Status.State), csvString(pc.Status.Health),
+			})
+		}
+
+		for _, psu := range power.PowerSupplies {
+			rows = append(rows, []string{
+				r.Hostname, power.ChassisID, "PowerSupply", csvString(psu.Name), csvString(psu.Model),
+				csvString(psu.SerialNumber), csvString(psu.FirmwareVersion), csvFloat(psu.LineInputVoltage),
+				csvFloat(psu.PowerCapacityWatts), csvFloat(psu.LastPowerOutputWatts), "", "", "", "", "", "", "",
+				csvString(psu.Status.State), csvString(psu.Status.Health),
+			})
+		}
+
+		for _, red := range power.Redundancy {
+			rows = append(rows, []string{
+				r.Hostname, power.ChassisID, "Redundancy", csvString(red.Name), "", "", "", "", "", "", "", "", "", "", "", "",
+				csvString(red.Mode), csvString(red.Status.State), csvString(red.Status.Health),
+			})
+		}
+	}
+
+	return formatCSV(powerCSVHeader, rows)
+}
+
+func printPower(r redfish.Redfish, plist []*PowerData, opts *OutputOptions) (string, error) {
+	if opts.Format == OutputJSON {
+		return printPowerJSON(r, plist), nil
+	}
+
+	if opts.Format == OutputJSONArray {
+		return formatJSONData(plist), nil
+	}
+
+	if opts.Format == OutputYAML {
+		return formatYAML(r, plist), nil
+	}
+
+	if opts.Format == OutputTemplate {
+		var list = make([]interface{}, 0, len(plist))
+		for _, power := range plist {
+			list = append(list, power)
+		}
+		return formatTemplate(r, opts, list...)
+	}
+
+	if opts.Format == OutputTable || opts.Format == OutputCSV {
+		return printPowerCSV(r, plist), nil
+	}
+
+	return printPowerText(r, plist), nil
+}
+
+func getPower(r redfish.Redfish, args []string, opts *OutputOptions, out io.Writer) error {
+	var plist = make([]*PowerData, 0)
+
+	argParse := flag.NewFlagSet("get-power", flag.ExitOnError)
+
+	var id = argParse.String("id", "", "Only read power data of chassis identified by ID")
+
+	argParse.Parse(args)
+
+	// Initialize session
+	err := r.Initialise()
+	if err != nil {
+		return fmt.Errorf("ERROR: Initialisation failed for %s: %s", r.Hostname, err.Error())
+	}
+
+	// Login
+	err = r.Login()
+	if err != nil {
+		return fmt.Errorf("ERROR: Login to %s failed: %s", r.Hostname, err.Error())
+	}
+
+	defer r.Logout()
+
+	clist, err := selectChassis(r, *id)
+	if err != nil {
+		return err
+	}
+
+	for _, chassis := range clist {
+		power, err := fetchPower(r, chassis)
+		if err != nil {
+			return err
+		}
+
+		// not every chassis provides power supplies
+		if power != nil {
+			plist = append(plist, power)
+		}
+	}
+
+	if *id != "" && len(plist) == 0 {
+		return fmt.Errorf("ERROR: Chassis %s on %s provides no power data", *id, r.Hostname)
+	}
+
+	output, err := printPower(r, plist, opts)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, output)
+
+	return nil
+}
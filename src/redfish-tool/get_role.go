@@ -7,7 +7,8 @@ import (
 	"fmt"
 	redfish "git.ypbind.de/repository/go-redfish.git"
 	log "github.com/sirupsen/logrus"
-	"os"
+	"io"
+	"strings"
 )
 
 func printRoleJSON(r redfish.Redfish, rle *redfish.RoleData) string {
@@ -17,7 +18,7 @@ func printRoleJSON(r redfish.Redfish, rle *redfish.RoleData) string {
 	if err != nil {
 		log.Panic(err)
 	}
-	result = fmt.Sprintf("{\"%s\":%s}", r.Hostname, string(str))
+	result = fmt.Sprintf("{\"%s\":%s}\n", r.Hostname, string(str))
 
 	return result
 
@@ -56,15 +57,49 @@ func printRoleText(r redfish.Redfish, rle *redfish.RoleData) string {
 	return result
 }
 
-func printRole(r redfish.Redfish, rle *redfish.RoleData, format uint) string {
-	if format == OutputJSON {
-		return printRoleJSON(r, rle)
+// roleCSVHeader - columns of -format=table and -format=csv for roles
+var roleCSVHeader = []string{"Hostname", "Id", "Name", "IsPredefined", "AssignedPrivileges", "Endpoint"}
+
+func roleCSVRow(r redfish.Redfish, rle *redfish.RoleData) []string {
+	return []string{
+		r.Hostname,
+		csvString(rle.ID),
+		csvString(rle.Name),
+		csvBool(rle.IsPredefined),
+		strings.Join(rle.AssignedPrivileges, ","),
+		csvString(rle.SelfEndpoint),
 	}
+}
 
-	return printRoleText(r, rle)
+func printRoleCSV(r redfish.Redfish, rle *redfish.RoleData) string {
+	return formatCSV(roleCSVHeader, [][]string{roleCSVRow(r, rle)})
 }
 
-func getRole(r redfish.Redfish, args []string, format uint) error {
+func printRole(r redfish.Redfish, rle *redfish.RoleData, opts *OutputOptions) (string, error) {
+	if opts.Format == OutputJSON {
+		return printRoleJSON(r, rle), nil
+	}
+
+	if opts.Format == OutputJSONArray {
+		return formatJSONData(rle), nil
+	}
+
+	if opts.Format == OutputYAML {
+		return formatYAML(r, rle), nil
+	}
+
+	if opts.Format == OutputTemplate {
+		return formatTemplate(r, opts, rle)
+	}
+
+	if opts.Format == OutputTable || opts.Format == OutputCSV {
+		return printRoleCSV(r, rle), nil
+	}
+
+	return printRoleText(r, rle), nil
+}
+
+func getRole(r redfish.Redfish, args []string, opts *OutputOptions, out io.Writer) error {
 	var rle *redfish.RoleData
 	var found bool
 	var rmap map[string]*redfish.RoleData
@@ -74,10 +109,8 @@ func getRole(r redfish.Redfish, args []string, format uint) error {
 
 	argParse.Parse(args)
 
-	fmt.Println(r.Hostname)
-
 	if *id == "" {
-		return errors.New("ERROR: Required option -id not found")
+		return usageError("ERROR: Required option -id not found")
 	}
 
 	// Initialize session
@@ -103,7 +136,9 @@ func getRole(r redfish.Redfish, args []string, format uint) error {
 	capa, found := redfish.VendorCapabilities[r.FlavorString]
 	if found {
 		if capa&redfish.HasAccountRoles != redfish.HasAccountRoles {
-			fmt.Println(r.Hostname)
+			if opts.Format == OutputText {
+				fmt.Fprintln(out, r.Hostname)
+			}
 			return errors.New("Vendor does not support roles")
 		}
 	}
@@ -118,9 +153,13 @@ func getRole(r redfish.Redfish, args []string, format uint) error {
 	rle, found = rmap[*id]
 
 	if found {
-		fmt.Println(printRole(r, rle, format))
+		output, err := printRole(r, rle, opts)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, output)
 	} else {
-		fmt.Fprintf(os.Stderr, "Role %s not found on %s\n", *id, r.Hostname)
+		return fmt.Errorf("ERROR: Role %s not found on %s", *id, r.Hostname)
 	}
 
 	return nil
@@ -0,0 +1,271 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	redfish "git.ypbind.de/repository/go-redfish.git"
+	log "github.com/sirupsen/logrus"
+	"io"
+	"strings"
+)
+
+func printStorageText(r redfish.Redfish, slist []*StorageData) string {
+	var result string
+
+	result = r.Hostname + "\n"
+	for _, storage := range slist {
+		result += " " + storage.SystemID
+		if storage.ID != nil {
+			result += " " + *storage.ID
+		}
+		result += "\n"
+
+		if storage.Name != nil {
+			result += "  Name: " + *storage.Name + "\n"
+		}
+		if storage.Status.Health != nil {
+			result += "  Health: " + *storage.Status.Health + "\n"
+		}
+		if storage.Status.HealthRollUp != nil {
+			result += "  HealthRollUp: " + *storage.Status.HealthRollUp + "\n"
+		}
+
+		result += "  Controllers:\n"
+		for _, ctrl := range storage.Controllers {
+			if ctrl.Name != nil {
+				result += "   " + *ctrl.Name + "\n"
+			} else if ctrl.MemberID != nil {
+				result += "   " + *ctrl.MemberID + "\n"
+			} else {
+				result += "   -\n"
+			}
+			if ctrl.Manufacturer != nil {
+				result += "    Manufacturer: " + *ctrl.Manufacturer + "\n"
+			}
+			if ctrl.Model != nil {
+				result += "    Model: " + *ctrl.Model + "\n"
+			}
+			if ctrl.SerialNumber != nil {
+				result += "    SerialNumber: " + *ctrl.SerialNumber + "\n"
+			}
+			if ctrl.FirmwareVersion != nil {
+				result += "    FirmwareVersion: " + *ctrl.FirmwareVersion + "\n"
+			}
+			if ctrl.Status.State != nil {
+				result += "    State: " + *ctrl.Status.State + "\n"
+			}
+			if ctrl.Status.Health != nil {
+				result += "    Health: " + *ctrl.Status.Health + "\n"
+			}
+		}
+
+		result += "  Volumes:\n"
+		for _, vol := range storage.Volumes {
+			if vol.ID != nil {
+				result += "   " + *vol.ID + "\n"
+			} else {
+				result += "   -\n"
+			}
+			if vol.Name != nil {
+				result += "    Name: " + *vol.Name + "\n"
+			}
+			if vol.RAIDType != nil {
+				result += "    RAIDType: " + *vol.RAIDType + "\n"
+			}
+			if vol.VolumeType != nil {
+				result += "    VolumeType: " + *vol.VolumeType + "\n"
+			}
+			if vol.CapacityBytes != nil {
+				result += "    CapacityBytes: " + csvFloat(vol.CapacityBytes) + "\n"
+			}
+			if vol.Status.State != nil {
+				result += "    State: " + *vol.Status.State + "\n"
+			}
+			if vol.Status.Health != nil {
+				result += "    Health: " + *vol.Status.Health + "\n"
+			}
+		}
+
+		result += "  Drives:\n"
+		for _, drive := range storage.Drives {
+			if drive.ID != nil {
+				result += "   " + *drive.ID + "\n"
+			} else {
+				result += "   -\n"
+			}
+			if drive.Name != nil {
+				result += "    Name: " + *drive.Name + "\n"
+			}
+			if drive.Manufacturer != nil {
+				result += "    Manufacturer: " + *drive.Manufacturer + "\n"
+			}
+			if drive.Model != nil {
+				result += "    Model: " + *drive.Model + "\n"
+			}
+			if drive.SerialNumber != nil {
+				result += "    SerialNumber: " + *drive.SerialNumber + "\n"
+			}
+			if drive.MediaType != nil {
+				result += "    MediaType: " + *drive.MediaType + "\n"
+			}
+			if drive.Protocol != nil {
+				result += "    Protocol: " + *drive.Protocol + "\n"
+			}
+			if drive.CapacityBytes != nil {
+				result += "    CapacityBytes: " + csvFloat(drive.CapacityBytes) + "\n"
+			}
+			if drive.PredictedMediaLifeLeftPercent != nil {
+				result += "    PredictedMediaLifeLeftPercent: " + csvFloat(drive.PredictedMediaLifeLeftPercent) + "\n"
+			}
+			if drive.FailurePredicted != nil {
+				result += "    FailurePredicted: " + csvBool(drive.FailurePredicted) + "\n"
+			}
+			if len(drive.Volumes) > 0 {
+				result += "    Volumes: " + strings.Join(drive.Volumes, ", ") + "\n"
+			}
+			if drive.Status.State != nil {
+				result += "    State: " + *drive.Status.State + "\n"
+			}
+			if drive.Status.Health != nil {
+				result += "    Health: " + *drive.Status.Health + "\n"
+			}
+		}
+	}
+
+	return result
+}
+
+func printStorageJSON(r redfish.Redfish, slist []*StorageData) string {
+	var result string
+
+	for _, storage := range slist {
+		str, err := json.Marshal(storage)
+		// Should NEVER happen!
+		if err != nil {
+			log.Panic(err)
+		}
+
+		result += fmt.Sprintf("{\"%s\":%s}\n", r.Hostname, string(str))
+	}
+
+	return result
+}
+
+// storageCSVHeader - columns of -format=table and -format=csv for storage, one row per controller, volume and drive
+var storageCSVHeader = []string{"Hostname", "System", "Storage", "Type", "Id", "Name", "Model", "SerialNumber", "FirmwareVersion", "RAIDType", "MediaType", "Protocol", "CapacityBytes", "PredictedMediaLifeLeftPercent", "FailurePredicted", "Volumes", "State", "Health"}
+
+func printStorageCSV(r redfish.Redfish, slist []*StorageData) string {
+	var rows [][]string
+
+	for _, storage := range slist {
+		sid := csvString(storage.ID)
+
+		for _, ctrl := range storage.Controllers {
+			rows = append(rows, []string{
+				r.Hostname, storage.SystemID, sid, "Controller", csvString(ctrl.MemberID), csvString(ctrl.Name),
+				csvString(ctrl.Model), csvString(ctrl.SerialNumber), csvString(ctrl.FirmwareVersion),
+				"", "", "", "", "", "", "",
+				csvString(ctrl.Status.State), csvString(ctrl.Status.Health),
+			})
+		}
+
+		for _, vol := range storage.Volumes {
+			rows = append(rows, []string{
+				r.Hostname, storage.SystemID, sid, "Volume", csvString(vol.ID), csvString(vol.Name),
+				"", "", "", csvString(vol.RAIDType), "", "", csvFloat(vol.CapacityBytes), "", "", "",
+				csvString(vol.Status.State), csvString(vol.Status.Health),
+			})
+		}
+
+		for _, drive := range storage.Drives {
+			rows = append(rows, []string{
+				r.Hostname, storage.SystemID, sid, "Drive", csvString(drive.ID), csvString(drive.Name),
+				csvString(drive.Model), csvString(drive.SerialNumber), "", "", csvString(drive.MediaType),
+				csvString(drive.Protocol), csvFloat(drive.CapacityBytes), csvFloat(drive.PredictedMediaLifeLeftPercent),
+				csvBool(drive.FailurePredicted), strings.Join(drive.Volumes, " "),
+				csvString(drive.Status.State), csvString(drive.Status.Health),
+			})
+		}
+	}
+
+	return formatCSV(storageCSVHeader, rows)
+}
+
+func printStorage(r redfish.Redfish, slist []*StorageData, opts *OutputOptions) (string, error) {
+	if opts.Format == OutputJSON {
+		return printStorageJSON(r, slist), nil
+	}
+
+	if opts.Format == OutputJSONArray {
+		return formatJSONData(slist), nil
+	}
+
+	if opts.Format == OutputYAML {
+		return formatYAML(r, slist), nil
+	}
+
+	if opts.Format == OutputTemplate {
+		var list = make([]interface{}, 0, len(slist))
+		for _, storage := range slist {
+			list = append(list, storage)
+		}
+		return formatTemplate(r, opts, list...)
+	}
+
+	if opts.Format == OutputTable || opts.Format == OutputCSV {
+		return printStorageCSV(r, slist), nil
+	}
+
+	return printStorageText(r, slist), nil
+}
+
+func getStorage(r redfish.Redfish, args []string, opts *OutputOptions, out io.Writer) error {
+	var slist = make([]*StorageData, 0)
+
+	argParse := flag.NewFlagSet("get-storage", flag.ExitOnError)
+
+	var uuid = argParse.String("uuid", "", "Get storage of system identified by UUID")
+	var id = argParse.String("id", "", "Get storage of system identified by ID")
+
+	argParse.Parse(args)
+
+	if *uuid != "" && *id != "" {
+		return usageError("ERROR: Options -uuid and -id are mutually exclusive")
+	}
+
+	// Initialize session
+	err := r.Initialise()
+	if err != nil {
+		return fmt.Errorf("ERROR: Initialisation failed for %s: %s", r.Hostname, err.Error())
+	}
+
+	// Login
+	err = r.Login()
+	if err != nil {
+		return fmt.Errorf("ERROR: Login to %s failed: %s", r.Hostname, err.Error())
+	}
+
+	defer r.Logout()
+
+	systems, err := selectSystems(r, *id, *uuid)
+	if err != nil {
+		return err
+	}
+
+	for _, sys := range systems {
+		storage, err := fetchStorage(r, sys)
+		if err != nil {
+			return err
+		}
+		slist = append(slist, storage...)
+	}
+
+	output, err := printStorage(r, slist, opts)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, output)
+
+	return nil
+}
@@ -2,12 +2,11 @@ package main
 
 import (
 	"encoding/json"
-	"errors"
 	"flag"
 	"fmt"
 	redfish "git.ypbind.de/repository/go-redfish.git"
 	log "github.com/sirupsen/logrus"
-	"os"
+	"io"
 )
 
 func printSystemJSON(r redfish.Redfish, sys *redfish.SystemData) string {
@@ -79,15 +78,56 @@ func printSystemText(r redfish.Redfish, sys *redfish.SystemData) string {
 	return result
 }
 
-func printSystem(r redfish.Redfish, sys *redfish.SystemData, format uint) string {
-	if format == OutputJSON {
-		return printSystemJSON(r, sys)
+// systemCSVHeader - columns of -format=table and -format=csv for systems
+var systemCSVHeader = []string{"Hostname", "Id", "UUID", "Name", "SerialNumber", "Manufacturer", "Model", "State", "Health", "HealthRollUp", "PowerState", "BIOSVersion", "SelfEndpoint"}
+
+func systemCSVRow(r redfish.Redfish, sys *redfish.SystemData) []string {
+	return []string{
+		r.Hostname,
+		csvString(sys.ID),
+		csvString(sys.UUID),
+		csvString(sys.Name),
+		csvString(sys.SerialNumber),
+		csvString(sys.Manufacturer),
+		csvString(sys.Model),
+		csvString(sys.Status.State),
+		csvString(sys.Status.Health),
+		csvString(sys.Status.HealthRollUp),
+		csvString(sys.PowerState),
+		csvString(sys.BIOSVersion),
+		csvString(sys.SelfEndpoint),
+	}
+}
+
+func printSystemCSV(r redfish.Redfish, sys *redfish.SystemData) string {
+	return formatCSV(systemCSVHeader, [][]string{systemCSVRow(r, sys)})
+}
+
+func printSystem(r redfish.Redfish, sys *redfish.SystemData, opts *OutputOptions) (string, error) {
+	if opts.Format == OutputJSON {
+		return printSystemJSON(r, sys), nil
+	}
+
+	if opts.Format == OutputJSONArray {
+		return formatJSONData(sys), nil
 	}
 
-	return printSystemText(r, sys)
+	if opts.Format == OutputYAML {
+		return formatYAML(r, sys), nil
+	}
+
+	if opts.Format == OutputTemplate {
+		return formatTemplate(r, opts, sys)
+	}
+
+	if opts.Format == OutputTable || opts.Format == OutputCSV {
+		return printSystemCSV(r, sys), nil
+	}
+
+	return printSystemText(r, sys), nil
 }
 
-func getSystem(r redfish.Redfish, args []string, format uint) error {
+func getSystem(r redfish.Redfish, args []string, opts *OutputOptions, out io.Writer) error {
 	var sys *redfish.SystemData
 	var found bool
 	var smap map[string]*redfish.SystemData
@@ -100,11 +140,11 @@ func getSystem(r redfish.Redfish, args []string, format uint) error {
 	argParse.Parse(args)
 
 	if *uuid != "" && *id != "" {
-		return errors.New("ERROR: Options -uuid and -id are mutually exclusive")
+		return usageError("ERROR: Options -uuid and -id are mutually exclusive")
 	}
 
 	if *uuid == "" && *id == "" {
-		return errors.New("ERROR: Required options -uuid or -id not found")
+		return usageError("ERROR: Required options -uuid or -id not found")
 	}
 
 	// Initialize session
@@ -139,12 +179,16 @@ func getSystem(r redfish.Redfish, args []string, format uint) error {
 	}
 
 	if found {
-		fmt.Println(printSystem(r, sys, format))
+		output, err := printSystem(r, sys, opts)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, output)
 	} else {
 		if *id != "" {
-			fmt.Fprintf(os.Stderr, "System %s not found on %s\n", *id, r.Hostname)
+			return fmt.Errorf("ERROR: System %s not found on %s", *id, r.Hostname)
 		} else {
-			fmt.Fprintf(os.Stderr, "System %s not found on %s\n", *uuid, r.Hostname)
+			return fmt.Errorf("ERROR: System %s not found on %s", *uuid, r.Hostname)
 		}
 
 	}
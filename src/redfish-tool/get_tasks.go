@@ -0,0 +1,66 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	redfish "git.ypbind.de/repository/go-redfish.git"
+	"io"
+)
+
+func getTasks(r redfish.Redfish, args []string, opts *OutputOptions, out io.Writer) error {
+	var tlist []*TaskData
+
+	argParse := flag.NewFlagSet("get-tasks", flag.ExitOnError)
+
+	var id = argParse.String("id", "", "Only show task identified by ID")
+	var uri = argParse.String("uri", "", "Only show task or task monitor identified by URI")
+
+	argParse.Parse(args)
+
+	if *id != "" && *uri != "" {
+		return usageError("ERROR: Options -id and -uri are mutually exclusive")
+	}
+
+	// Initialize session
+	err := r.Initialise()
+	if err != nil {
+		return fmt.Errorf("ERROR: Initialisation failed for %s: %s", r.Hostname, err.Error())
+	}
+
+	// Login
+	err = r.Login()
+	if err != nil {
+		return fmt.Errorf("ERROR: Login to %s failed: %s", r.Hostname, err.Error())
+	}
+
+	defer r.Logout()
+
+	if *id != "" {
+		endpoint, err := taskEndpointByID(r, *id)
+		if err != nil {
+			return err
+		}
+		*uri = endpoint
+	}
+
+	if *uri != "" {
+		task, err := fetchTask(r, *uri)
+		if err != nil {
+			return err
+		}
+		tlist = []*TaskData{task}
+	} else {
+		tlist, err = fetchAllTasks(r)
+		if err != nil {
+			return err
+		}
+	}
+
+	output, err := printTasks(r, tlist, opts)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, output)
+
+	return nil
+}
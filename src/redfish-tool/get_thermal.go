@@ -0,0 +1,208 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	redfish "git.ypbind.de/repository/go-redfish.git"
+	log "github.com/sirupsen/logrus"
+	"io"
+)
+
+// sensorText - sensor reading, thresholds and health as text, every line is prefixed by indent
+func sensorText(s SensorReading, indent string) string {
+	var result string
+
+	if s.Name != nil {
+		result += indent + *s.Name + "\n"
+	} else {
+		result += indent + "-\n"
+	}
+
+	if s.Reading != nil {
+		result += indent + " Reading: " + csvFloat(s.Reading)
+		if s.ReadingUnits != nil {
+			result += " " + *s.ReadingUnits
+		}
+		result += "\n"
+	}
+
+	if s.LowerThresholdCritical != nil {
+		result += indent + " LowerThresholdCritical: " + csvFloat(s.LowerThresholdCritical) + "\n"
+	}
+	if s.UpperThresholdCritical != nil {
+		result += indent + " UpperThresholdCritical: " + csvFloat(s.UpperThresholdCritical) + "\n"
+	}
+	if s.LowerThresholdFatal != nil {
+		result += indent + " LowerThresholdFatal: " + csvFloat(s.LowerThresholdFatal) + "\n"
+	}
+	if s.UpperThresholdFatal != nil {
+		result += indent + " UpperThresholdFatal: " + csvFloat(s.UpperThresholdFatal) + "\n"
+	}
+
+	if s.Status.State != nil {
+		result += indent + " State: " + *s.Status.State + "\n"
+	}
+	if s.Status.Health != nil {
+		result += indent + " Health: " + *s.Status.Health + "\n"
+	}
+
+	return result
+}
+
+func printThermalText(r redfish.Redfish, tlist []*ThermalData) string {
+	var result string
+
+	result = r.Hostname + "\n"
+	for _, thermal := range tlist {
+		result += " " + thermal.ChassisID + "\n"
+
+		result += "  Temperatures:\n"
+		for _, t := range thermal.Temperatures {
+			result += sensorText(t, "   ")
+		}
+
+		result += "  Fans:\n"
+		for _, f := range thermal.Fans {
+			result += sensorText(f, "   ")
+		}
+
+		if thermal.SelfEndpoint != nil {
+			result += "  SelfEndpoint: " + *thermal.SelfEndpoint + "\n"
+		}
+	}
+
+	return result
+}
+
+func printThermalJSON(r redfish.Redfish, tlist []*ThermalData) string {
+	var result string
+
+	for _, thermal := range tlist {
+		str, err := json.Marshal(thermal)
+		// Should NEVER happen!
+		if err != nil {
+			log.Panic(err)
+		}
+
+		result += fmt.Sprintf("{\"%s\":%s}\n", r.Hostname, string(str))
+	}
+
+	return result
+}
+
+// sensorCSVHeader - columns of -format=table and -format=csv for sensors, one row per sensor
+var sensorCSVHeader = []string{"Hostname", "Chassis", "Type", "Name", "Reading", "ReadingUnits", "LowerThresholdCritical", "UpperThresholdCritical", "LowerThresholdFatal", "UpperThresholdFatal", "State", "Health"}
+
+func sensorCSVRow(r redfish.Redfish, chassis string, stype string, s SensorReading) []string {
+	return []string{
+		r.Hostname,
+		chassis,
+		stype,
+		csvString(s.Name),
+		csvFloat(s.Reading),
+		csvString(s.ReadingUnits),
+		csvFloat(s.LowerThresholdCritical),
+		csvFloat(s.UpperThresholdCritical),
+		csvFloat(s.LowerThresholdFatal),
+		csvFloat(s.UpperThresholdFatal),
+		csvString(s.Status.State),
+		csvString(s.Status.Health),
+	}
+}
+
+func printThermalCSV(r redfish.Redfish, tlist []*ThermalData) string {
+	var rows [][]string
+
+	for _, thermal := range tlist {
+		for _, t := range thermal.Temperatures {
+			rows = append(rows, sensorCSVRow(r, thermal.ChassisID, "Temperature", t))
+		}
+		for _, f := range thermal.Fans {
+			rows = append(rows, sensorCSVRow(r, thermal.ChassisID, "Fan", f))
+		}
+	}
+
+	return formatCSV(sensorCSVHeader, rows)
+}
+
+func printThermal(r redfish.Redfish, tlist []*ThermalData, opts *OutputOptions) (string, error) {
+	if opts.Format == OutputJSON {
+		return printThermalJSON(r, tlist), nil
+	}
+
+	if opts.Format == OutputJSONArray {
+		return formatJSONData(tlist), nil
+	}
+
+	if opts.Format == OutputYAML {
+		return formatYAML(r, tlist), nil
+	}
+
+	if opts.Format == OutputTemplate {
+		var list = make([]interface{}, 0, len(tlist))
+		for _, thermal := range tlist {
+			list = append(list, thermal)
+		}
+		return formatTemplate(r, opts, list...)
+	}
+
+	if opts.Format == OutputTable || opts.Format == OutputCSV {
+		return printThermalCSV(r, tlist), nil
+	}
+
+	return printThermalText(r, tlist), nil
+}
+
+func getThermal(r redfish.Redfish, args []string, opts *OutputOptions, out io.Writer) error {
+	var tlist = make([]*ThermalData, 0)
+
+	argParse := flag.NewFlagSet("get-thermal", flag.ExitOnError)
+
+	var id = argParse.String("id", "", "Only read sensors of chassis identified by ID")
+
+	argParse.Parse(args)
+
+	// Initialize session
+	err := r.Initialise()
+	if err != nil {
+		return fmt.Errorf("ERROR: Initialisation failed for %s: %s", r.Hostname, err.Error())
+	}
+
+	// Login
+	err = r.Login()
+	if err != nil {
+		return fmt.Errorf("ERROR: Login to %s failed: %s", r.Hostname, err.Error())
+	}
+
+	defer r.Logout()
+
+	clist, err := selectChassis(r, *id)
+	if err != nil {
+		return err
+	}
+
+	for _, chassis := range clist {
+		thermal, err := fetchThermal(r, chassis)
+		if err != nil {
+			return err
+		}
+
+		// not every chassis (e.g. a drive enclosure) provides temperature and fan sensors
+		if thermal != nil {
+			tlist = append(tlist, thermal)
+		}
+	}
+
+	if *id != "" && len(tlist) == 0 {
+		return fmt.Errorf("ERROR: Chassis %s on %s provides no thermal data", *id, r.Hostname)
+	}
+
+	output, err := printThermal(r, tlist, opts)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, output)
+
+	return nil
+}
@@ -2,12 +2,11 @@ package main
 
 import (
 	"encoding/json"
-	"errors"
 	"flag"
 	"fmt"
 	redfish "git.ypbind.de/repository/go-redfish.git"
 	log "github.com/sirupsen/logrus"
-	"os"
+	"io"
 )
 
 func printUserText(r redfish.Redfish, acc *redfish.AccountData) string {
@@ -65,20 +64,56 @@ func printUserJSON(r redfish.Redfish, acc *redfish.AccountData) string {
 	if err != nil {
 		log.Panic(err)
 	}
-	result = fmt.Sprintf("{\"%s\":%s}", r.Hostname, string(str))
+	result = fmt.Sprintf("{\"%s\":%s}\n", r.Hostname, string(str))
 
 	return result
 }
 
-func printUser(r redfish.Redfish, acc *redfish.AccountData, format uint) string {
-	if format == OutputJSON {
-		return printUserJSON(r, acc)
+// userCSVHeader - columns of -format=table and -format=csv for accounts
+var userCSVHeader = []string{"Hostname", "Id", "Name", "UserName", "RoleId", "Enabled", "Locked", "Endpoint"}
+
+func userCSVRow(r redfish.Redfish, acc *redfish.AccountData) []string {
+	return []string{
+		r.Hostname,
+		csvString(acc.ID),
+		csvString(acc.Name),
+		csvString(acc.UserName),
+		csvString(acc.RoleID),
+		csvBool(acc.Enabled),
+		csvBool(acc.Locked),
+		csvString(acc.SelfEndpoint),
 	}
+}
 
-	return printUserText(r, acc)
+func printUserCSV(r redfish.Redfish, acc *redfish.AccountData) string {
+	return formatCSV(userCSVHeader, [][]string{userCSVRow(r, acc)})
 }
 
-func getUser(r redfish.Redfish, args []string, format uint) error {
+func printUser(r redfish.Redfish, acc *redfish.AccountData, opts *OutputOptions) (string, error) {
+	if opts.Format == OutputJSON {
+		return printUserJSON(r, acc), nil
+	}
+
+	if opts.Format == OutputJSONArray {
+		return formatJSONData(acc), nil
+	}
+
+	if opts.Format == OutputYAML {
+		return formatYAML(r, acc), nil
+	}
+
+	if opts.Format == OutputTemplate {
+		return formatTemplate(r, opts, acc)
+	}
+
+	if opts.Format == OutputTable || opts.Format == OutputCSV {
+		return printUserCSV(r, acc), nil
+	}
+
+	return printUserText(r, acc), nil
+}
+
+func getUser(r redfish.Redfish, args []string, opts *OutputOptions, out io.Writer) error {
 	var acc *redfish.AccountData
 	var found bool
 	var amap map[string]*redfish.AccountData
@@ -90,11 +125,11 @@ func getUser(r redfish.Redfish, args []string, format uint) error {
 	argParse.Parse(args)
 
 	if *name != "" && *id != "" {
-		return errors.New("ERROR: Options -name and -id are mutually exclusive")
+		return usageError("ERROR: Options -name and -id are mutually exclusive")
 	}
 
 	if *name == "" && *id == "" {
-		return errors.New("ERROR: Required options -name or -id not found")
+		return usageError("ERROR: Required options -name or -id not found")
 	}
 
 	// Initialize session
@@ -129,12 +164,16 @@ func getUser(r redfish.Redfish, args []string, format uint) error {
 	}
 
 	if found {
-		fmt.Println(printUser(r, acc, format))
+		output, err := printUser(r, acc, opts)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, output)
 	} else {
 		if *id != "" {
-			fmt.Fprintf(os.Stderr, "User %s not found on %s\n", *id, r.Hostname)
+			return fmt.Errorf("ERROR: User %s not found on %s", *id, r.Hostname)
 		} else {
-			fmt.Fprintf(os.Stderr, "User %s not found on %s\n", *name, r.Hostname)
+			return fmt.Errorf("ERROR: User %s not found on %s", *name, r.Hostname)
 		}
 	}
 
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// HostEntry - host to connect to, optionally with its own port and user
+type HostEntry struct {
+	Hostname string
+	Port     *int
+	User     *string
+}
+
+// Inventory - hosts and host groups read from a hosts file
+type Inventory struct {
+	Hosts  []HostEntry
+	Groups map[string][]HostEntry
+}
+
+func (h HostEntry) String() string {
+	if h.Port != nil {
+		return net.JoinHostPort(h.Hostname, strconv.Itoa(*h.Port))
+	}
+	return h.Hostname
+}
+
+// isGroupHeader - line is a group header [<group>], bracketed IP addresses (e.g. [fe80::1]) are hosts
+func isGroupHeader(line string) bool {
+	if !strings.HasPrefix(line, "[") || !strings.HasSuffix(line, "]") || strings.Contains(line, " ") {
+		return false
+	}
+
+	// strip the zone of link-local IPv6 addresses, e.g. [fe80::1%eth0]
+	addr := strings.SplitN(line[1:len(line)-1], "%", 2)[0]
+	return net.ParseIP(addr) == nil
+}
+
+// splitHostPort - split host[:port], IPv6 addresses with port must be enclosed in brackets
+func splitHostPort(s string) (string, *int, error) {
+	var host string
+	var sport string
+	var err error
+
+	if strings.HasPrefix(s, "[") {
+		if strings.HasSuffix(s, "]") {
+			return s[1 : len(s)-1], nil, nil
+		}
+		host, sport, err = net.SplitHostPort(s)
+		if err != nil {
+			return "", nil, err
+		}
+	} else if strings.Count(s, ":") == 1 {
+		host, sport, err = net.SplitHostPort(s)
+		if err != nil {
+			return "", nil, err
+		}
+	} else {
+		return s, nil, nil
+	}
+
+	port, err := strconv.Atoi(sport)
+	if err != nil || port <= 0 || port > 65535 {
+		return "", nil, fmt.Errorf("ERROR: Invalid port %s for host %s", sport, host)
+	}
+
+	return host, &port, nil
+}
+
+// parseHostEntry - parse host[:port] [user=<user>] [port=<port>]
+func parseHostEntry(s string) (HostEntry, error) {
+	var entry HostEntry
+	var err error
+
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return entry, fmt.Errorf("ERROR: Empty host entry")
+	}
+
+	entry.Hostname, entry.Port, err = splitHostPort(fields[0])
+	if err != nil {
+		return entry, err
+	}
+
+	for _, f := range fields[1:] {
+		kv := strings.SplitN(f, "=", 2)
+		if len(kv) != 2 || kv[1] == "" {
+			return entry, fmt.Errorf("ERROR: Invalid setting %s for host %s", f, entry.Hostname)
+		}
+
+		switch strings.ToLower(kv[0]) {
+		case "user":
+			user := kv[1]
+			entry.User = &user
+		case "port":
+			port, err := strconv.Atoi(kv[1])
+			if err != nil || port <= 0 || port > 65535 {
+				return entry, fmt.Errorf("ERROR: Invalid port %s for host %s", kv[1], entry.Hostname)
+			}
+			entry.Port = &port
+		default:
+			return entry, fmt.Errorf("ERROR: Unknown setting %s for host %s", kv[0], entry.Hostname)
+		}
+	}
+
+	return entry, nil
+}
+
+// readHostsFile - read hosts file, one host per line, a line [<group>] starts a new group
+func readHostsFile(f string) (*Inventory, error) {
+	var fd *os.File
+	var err error
+	var group string
+	var lineno int
+	var inv = Inventory{
+		Groups: make(map[string][]HostEntry),
+	}
+	var seen = make(map[string]bool)
+
+	if f == "-" {
+		fd = os.Stdin
+	} else {
+		fd, err = os.Open(f)
+		if err != nil {
+			return nil, err
+		}
+		defer fd.Close()
+	}
+
+	scanner := bufio.NewScanner(fd)
+	for scanner.Scan() {
+		lineno++
+
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if isGroupHeader(line) {
+			group = strings.TrimSpace(line[1 : len(line)-1])
+			if group == "" {
+				return nil, fmt.Errorf("ERROR: Empty group name in %s, line %d", f, lineno)
+			}
+			if _, found := inv.Groups[group]; !found {
+				inv.Groups[group] = make([]HostEntry, 0)
+			}
+			continue
+		}
+
+		entry, err := parseHostEntry(line)
+		if err != nil {
+			return nil, fmt.Errorf("%s (%s, line %d)", err.Error(), f, lineno)
+		}
+
+		if group != "" {
+			inv.Groups[group] = append(inv.Groups[group], entry)
+		}
+
+		if !seen[entry.String()] {
+			inv.Hosts = append(inv.Hosts, entry)
+			seen[entry.String()] = true
+		}
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return &inv, nil
+}
+
+// expandHostList - expand comma separated list of hosts and @<group> references to the hosts file
+func expandHostList(hosts string, inv *Inventory) ([]HostEntry, error) {
+	var result []HostEntry
+	var seen = make(map[string]bool)
+
+	for _, h := range strings.Split(hosts, ",") {
+		var entries []HostEntry
+
+		h = strings.TrimSpace(h)
+		if h == "" {
+			continue
+		}
+
+		if strings.HasPrefix(h, "@") {
+			if inv == nil {
+				return nil, fmt.Errorf("ERROR: Host group %s requires a hosts file", h)
+			}
+			group, found := inv.Groups[h[1:]]
+			if !found {
+				return nil, fmt.Errorf("ERROR: Host group %s not found in hosts file", h)
+			}
+			entries = group
+		} else {
+			entry, err := parseHostEntry(h)
+			if err != nil {
+				return nil, err
+			}
+
+			// use settings from the hosts file if nothing else was given for this host
+			if inv != nil && entry.Port == nil && entry.User == nil {
+				for _, e := range inv.Hosts {
+					if e.Hostname == entry.Hostname {
+						entry = e
+						break
+					}
+				}
+			}
+			entries = []HostEntry{entry}
+		}
+
+		for _, entry := range entries {
+			if !seen[entry.String()] {
+				result = append(result, entry)
+				seen[entry.String()] = true
+			}
+		}
+	}
+
+	return result, nil
+}
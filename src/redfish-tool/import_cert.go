@@ -5,11 +5,12 @@ import (
 	"flag"
 	"fmt"
 	redfish "git.ypbind.de/repository/go-redfish.git"
+	"io"
 	"io/ioutil"
 	"os"
 )
 
-func importCertificate(r redfish.Redfish, args []string) error {
+func importCertificate(r redfish.Redfish, args []string, opts *OutputOptions, out io.Writer) error {
 	var rawPem []byte
 	var err error
 
@@ -20,7 +21,7 @@ func importCertificate(r redfish.Redfish, args []string) error {
 	argParse.Parse(args)
 
 	if *pem == "" {
-		return errors.New("ERROR: Missing mandatory parameter -certificate")
+		return usageError("ERROR: Missing mandatory parameter -certificate")
 	}
 
 	if *pem == "-" {
@@ -55,7 +56,9 @@ func importCertificate(r redfish.Redfish, args []string) error {
 	capa, found := redfish.VendorCapabilities[r.FlavorString]
 	if found {
 		if capa&redfish.HasSecurityService != redfish.HasSecurityService {
-			fmt.Println(r.Hostname)
+			if opts.Format == OutputText {
+				fmt.Fprintln(out, r.Hostname)
+			}
 			return errors.New("Vendor does not support certificate import")
 		}
 	}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	redfish "git.ypbind.de/repository/go-redfish.git"
+	"io"
+	"strings"
+)
+
+// insertMediaOptions - options of insert-media
+type insertMediaOptions struct {
+	Manager        *string
+	System         *string
+	Media          *string
+	Image          *string
+	WriteProtected *bool
+	User           *string
+	Password       *string
+	PasswordFile   *string
+	Protocol       *string
+}
+
+// parseInsertMediaOptions - parse and check the options of insert-media
+func parseInsertMediaOptions(args []string) (*insertMediaOptions, error) {
+	argParse := flag.NewFlagSet("insert-media", flag.ExitOnError)
+
+	var o = insertMediaOptions{
+		Manager:        argParse.String("manager", "", "Use virtual media of manager identified by ID"),
+		System:         argParse.String("system", "", "Use virtual media of system identified by ID"),
+		Media:          argParse.String("media", "", "Insert image into virtual media identified by ID"),
+		Image:          argParse.String("image", "", "URL of the image to insert"),
+		WriteProtected: argParse.Bool("write-protected", true, "Insert image write protected"),
+		User:           argParse.String("user", "", "User name to access the image"),
+		Password:       argParse.String("password", "", "Password to access the image"),
+		PasswordFile:   argParse.String("password-file", "", "Read password to access the image from file"),
+		Protocol:       argParse.String("protocol", "", "Transfer protocol used to access the image, e.g. HTTP, HTTPS, NFS or CIFS"),
+	}
+
+	argParse.Parse(args)
+
+	if *o.Manager != "" && *o.System != "" {
+		return nil, usageError("ERROR: Options -manager and -system are mutually exclusive")
+	}
+
+	if *o.Image == "" {
+		return nil, usageError("ERROR: Option -image is mandatory")
+	}
+
+	if *o.Password != "" && *o.PasswordFile != "" {
+		return nil, usageError("ERROR: Options -password and -password-file are mutually exclusive")
+	}
+
+	if (*o.Password != "" || *o.PasswordFile != "") && *o.User == "" {
+		return nil, usageError("ERROR: Password requires option -user")
+	}
+
+	return &o, nil
+}
+
+// prepareInsertMedia - read the password from -password-file once for all hosts, standard input can only be read once
+// The password is passed to insertMedia by -password
+func prepareInsertMedia(args []string) ([]string, error) {
+	o, err := parseInsertMediaOptions(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if *o.PasswordFile == "" {
+		return args, nil
+	}
+
+	pass, err := readSingleLine(*o.PasswordFile)
+	if err != nil {
+		return nil, fmt.Errorf("ERROR: Unable to read password to access the image from file: %s", err.Error())
+	}
+
+	// options set later take precedence
+	return append(args, "-password-file=", "-password="+pass), nil
+}
+
+func insertMedia(r redfish.Redfish, args []string, opts *OutputOptions, out io.Writer) error {
+	var vm *VirtualMediaData
+
+	// -password-file has already been read by prepareInsertMedia
+	o, err := parseInsertMediaOptions(args)
+	if err != nil {
+		return err
+	}
+
+	// Initialize session
+	err = r.Initialise()
+	if err != nil {
+		return fmt.Errorf("ERROR: Initialisation failed for %s: %s", r.Hostname, err.Error())
+	}
+
+	// Login
+	err = r.Login()
+	if err != nil {
+		return fmt.Errorf("ERROR: Login to %s failed: %s", r.Hostname, err.Error())
+	}
+
+	defer r.Logout()
+
+	// some vendors provide OEM actions for virtual media
+	err = r.GetVendorFlavor()
+	if err != nil {
+		return err
+	}
+
+	vlist, err := selectVirtualMedia(r, *o.Manager, *o.System, *o.Media)
+	if err != nil {
+		return err
+	}
+
+	if *o.Media != "" {
+		if len(vlist) > 1 {
+			return fmt.Errorf("ERROR: Virtual media %s found on more than one manager or system of %s, use -manager or -system", *o.Media, r.Hostname)
+		}
+		vm = vlist[0]
+	} else {
+		// default to the first CD/DVD drive
+		for _, v := range vlist {
+			if v.supportsMediaType("CD", "DVD") {
+				vm = v
+				break
+			}
+		}
+		if vm == nil {
+			return fmt.Errorf("ERROR: No virtual CD/DVD drive found on %s, use -media", r.Hostname)
+		}
+	}
+
+	if opts.Format == OutputText {
+		fmt.Fprintln(out, r.Hostname)
+	}
+
+	return insertVirtualMedia(r, vm, VirtualMediaInsertData{
+		Image:                *o.Image,
+		WriteProtected:       *o.WriteProtected,
+		UserName:             *o.User,
+		Password:             *o.Password,
+		TransferProtocolType: strings.ToUpper(*o.Protocol),
+	})
+}
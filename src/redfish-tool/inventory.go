@@ -0,0 +1,254 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	redfish "git.ypbind.de/repository/go-redfish.git"
+)
+
+// InventoryItem - processor, memory module, PCIe device or network adapter of a system
+type InventoryItem struct {
+	ID              *string        `json:"Id"`
+	Name            *string        `json:"Name"`
+	Manufacturer    *string        `json:"Manufacturer"`
+	Model           *string        `json:"Model"`
+	PartNumber      *string        `json:"PartNumber"`
+	SerialNumber    *string        `json:"SerialNumber"`
+	FirmwareVersion *string        `json:"FirmwareVersion"`
+	Slot            *string        `json:"Slot"`
+	Cores           *float64       `json:"Cores"`
+	Threads         *float64       `json:"Threads"`
+	SpeedMHz        *float64       `json:"SpeedMHz"`
+	CapacityMiB     *float64       `json:"CapacityMiB"`
+	Status          redfish.Status `json:"Status"`
+	SelfEndpoint    *string
+}
+
+// InventoryData - hardware inventory of a system
+type InventoryData struct {
+	SystemID        string          `json:"SystemId"`
+	Processors      []InventoryItem `json:"Processors"`
+	Memory          []InventoryItem `json:"Memory"`
+	PCIeDevices     []InventoryItem `json:"PCIeDevices"`
+	NetworkAdapters []InventoryItem `json:"NetworkAdapters"`
+}
+
+// systemResource - links of a system to its hardware collections, not provided by redfish.SystemData
+type systemResource struct {
+	Processors        *OData  `json:"Processors"`
+	Memory            *OData  `json:"Memory"`
+	PCIeDevices       []OData `json:"PCIeDevices"`
+	NetworkInterfaces *OData  `json:"NetworkInterfaces"`
+	Storage           *OData  `json:"Storage"`
+	Bios              *OData  `json:"Bios"`
+}
+
+// inventoryResource - attributes of processors, memory, PCIe devices, network interfaces and adapters
+type inventoryResource struct {
+	ID                *string  `json:"Id"`
+	Name              *string  `json:"Name"`
+	Manufacturer      *string  `json:"Manufacturer"`
+	Model             *string  `json:"Model"`
+	PartNumber        *string  `json:"PartNumber"`
+	SerialNumber      *string  `json:"SerialNumber"`
+	FirmwareVersion   *string  `json:"FirmwareVersion"`
+	Socket            *string  `json:"Socket"`
+	TotalCores        *float64 `json:"TotalCores"`
+	TotalThreads      *float64 `json:"TotalThreads"`
+	MaxSpeedMHz       *float64 `json:"MaxSpeedMHz"`
+	OperatingSpeedMhz *float64 `json:"OperatingSpeedMhz"`
+	CapacityMiB       *float64 `json:"CapacityMiB"`
+	DeviceLocator     *string  `json:"DeviceLocator"`
+	MemoryLocation    *struct {
+		Socket *float64 `json:"Socket"`
+		Slot   *float64 `json:"Slot"`
+	} `json:"MemoryLocation"`
+	Links struct {
+		NetworkAdapter *OData `json:"NetworkAdapter"`
+	} `json:"Links"`
+	Status redfish.Status `json:"Status"`
+}
+
+func (res inventoryResource) item(endpoint string) InventoryItem {
+	result := InventoryItem{
+		ID:              res.ID,
+		Name:            res.Name,
+		Manufacturer:    res.Manufacturer,
+		Model:           res.Model,
+		PartNumber:      res.PartNumber,
+		SerialNumber:    res.SerialNumber,
+		FirmwareVersion: res.FirmwareVersion,
+		Slot:            res.Socket,
+		Cores:           res.TotalCores,
+		Threads:         res.TotalThreads,
+		SpeedMHz:        res.MaxSpeedMHz,
+		CapacityMiB:     res.CapacityMiB,
+		Status:          res.Status,
+		SelfEndpoint:    &endpoint,
+	}
+
+	if res.OperatingSpeedMhz != nil {
+		result.SpeedMHz = res.OperatingSpeedMhz
+	}
+
+	if res.DeviceLocator != nil {
+		result.Slot = res.DeviceLocator
+	} else if res.MemoryLocation != nil && res.MemoryLocation.Slot != nil {
+		slot := strconv.FormatFloat(*res.MemoryLocation.Slot, 'f', -1, 64)
+		result.Slot = &slot
+	}
+
+	return result
+}
+
+// absent - empty sockets and slots are reported with the state Absent
+func (res inventoryResource) absent() bool {
+	return res.Status.State != nil && *res.Status.State == "Absent"
+}
+
+// fetchInventoryItems - all installed items of a list of endpoints
+func fetchInventoryItems(r redfish.Redfish, endpoints []string) ([]InventoryItem, error) {
+	var result = make([]InventoryItem, 0)
+
+	for _, ep := range endpoints {
+		var res inventoryResource
+
+		err := redfishGet(r, ep, &res)
+		if err != nil {
+			return nil, err
+		}
+
+		if !res.absent() {
+			result = append(result, res.item(ep))
+		}
+	}
+
+	return result, nil
+}
+
+// fetchCollectionItems - all installed items of a collection, an empty list if the collection is not provided
+func fetchCollectionItems(r redfish.Redfish, collection *OData) ([]InventoryItem, error) {
+	if collection == nil || collection.ID == nil {
+		return make([]InventoryItem, 0), nil
+	}
+
+	members, err := collectionMembers(r, *collection.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return fetchInventoryItems(r, members)
+}
+
+// selectSystems - system identified by id or uuid or all systems if both are empty, sorted by ID
+func selectSystems(r redfish.Redfish, id string, uuid string) ([]*redfish.SystemData, error) {
+	var smap map[string]*redfish.SystemData
+	var err error
+	var result []*redfish.SystemData
+
+	if uuid != "" {
+		smap, err = r.MapSystemsByUUID()
+	} else {
+		smap, err = r.MapSystemsByID()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if id != "" || uuid != "" {
+		key := id
+		if uuid != "" {
+			key = uuid
+		}
+
+		sys, found := smap[key]
+		if !found {
+			return nil, fmt.Errorf("ERROR: System %s not found on %s", key, r.Hostname)
+		}
+		return []*redfish.SystemData{sys}, nil
+	}
+
+	for _, key := range sortedKeys(smap, &OutputOptions{}) {
+		result = append(result, smap[key])
+	}
+
+	return result, nil
+}
+
+// fetchInventory - processors, memory, PCIe devices and network adapters of a system
+func fetchInventory(r redfish.Redfish, sys *redfish.SystemData) (*InventoryData, error) {
+	var result InventoryData
+	var system systemResource
+
+	if sys.ID != nil {
+		result.SystemID = *sys.ID
+	}
+
+	if sys.SelfEndpoint == nil {
+		return nil, fmt.Errorf("ERROR: No endpoint for system %s on %s", result.SystemID, r.Hostname)
+	}
+
+	err := redfishGet(r, *sys.SelfEndpoint, &system)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Processors, err = fetchCollectionItems(r, system.Processors)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Memory, err = fetchCollectionItems(r, system.Memory)
+	if err != nil {
+		return nil, err
+	}
+
+	result.PCIeDevices, err = fetchInventoryItems(r, odataList(system.PCIeDevices))
+	if err != nil {
+		return nil, err
+	}
+
+	// network interfaces only carry the name and status, model and serial number are provided by the adapter
+	result.NetworkAdapters = make([]InventoryItem, 0)
+	if system.NetworkInterfaces != nil && system.NetworkInterfaces.ID != nil {
+		members, err := collectionMembers(r, *system.NetworkInterfaces.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		// ports of the same adapter are separate network interfaces
+		var seen = make(map[string]bool)
+		for _, member := range members {
+			var nic inventoryResource
+
+			err = redfishGet(r, member, &nic)
+			if err != nil {
+				return nil, err
+			}
+
+			if nic.absent() {
+				continue
+			}
+
+			if nic.Links.NetworkAdapter == nil || nic.Links.NetworkAdapter.ID == nil {
+				result.NetworkAdapters = append(result.NetworkAdapters, nic.item(member))
+				continue
+			}
+
+			if seen[*nic.Links.NetworkAdapter.ID] {
+				continue
+			}
+			seen[*nic.Links.NetworkAdapter.ID] = true
+
+			adapters, err := fetchInventoryItems(r, []string{*nic.Links.NetworkAdapter.ID})
+			if err != nil {
+				return nil, err
+			}
+			result.NetworkAdapters = append(result.NetworkAdapters, adapters...)
+		}
+	}
+
+	return &result, nil
+}
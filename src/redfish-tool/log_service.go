@@ -0,0 +1,205 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	redfish "git.ypbind.de/repository/go-redfish.git"
+)
+
+// LogEntryData - entry of a log service (e.g. SEL, IML or Lifecycle log)
+type LogEntryData struct {
+	ID           *string `json:"Id"`
+	Name         *string `json:"Name"`
+	EntryType    *string `json:"EntryType"`
+	Severity     *string `json:"Severity"`
+	Created      *string `json:"Created"`
+	Message      *string `json:"Message"`
+	MessageID    *string `json:"MessageId"`
+	SensorType   *string `json:"SensorType"`
+	SelfEndpoint *string
+}
+
+// LogData - log service of a manager or system with its entries
+type LogData struct {
+	SourceType   string         `json:"SourceType"`
+	SourceID     string         `json:"SourceId"`
+	ID           *string        `json:"Id"`
+	Name         *string        `json:"Name"`
+	Entries      []LogEntryData `json:"Entries"`
+	Status       redfish.Status `json:"Status"`
+	ClearLog     *string        `json:"-"`
+	SelfEndpoint *string
+}
+
+type logServiceResource struct {
+	ID      *string        `json:"Id"`
+	Name    *string        `json:"Name"`
+	Entries *OData         `json:"Entries"`
+	Status  redfish.Status `json:"Status"`
+	Actions struct {
+		ClearLog *struct {
+			Target *string `json:"target"`
+		} `json:"#LogService.ClearLog"`
+	} `json:"Actions"`
+}
+
+type logEntryResource struct {
+	LogEntryData
+	OData
+}
+
+type logEntryCollection struct {
+	Members  []logEntryResource `json:"Members"`
+	NextLink *string            `json:"Members@odata.nextLink"`
+}
+
+// logSeverity - order of the Severity values of log entries, unknown values are treated as OK
+var logSeverity = map[string]int{
+	"ok":       0,
+	"warning":  1,
+	"critical": 2,
+}
+
+// logTimeFormats - accepted formats of the -since option
+var logTimeFormats = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// parseLogTime - parse the time of the -since option, times without time zone are local times
+func parseLogTime(s string) (time.Time, error) {
+	for _, f := range logTimeFormats {
+		t, err := time.ParseInLocation(f, s, time.Local)
+		if err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("ERROR: Invalid time %s, use RFC3339 format (e.g. 2006-01-02T15:04:05Z07:00) or YYYY-MM-DD", s)
+}
+
+// fetchLogServices - log services of a manager or system, only the log service logID if set,
+// the entries are only read if withEntries is set
+func fetchLogServices(r redfish.Redfish, src resourceOwner, logID string, withEntries bool) ([]*LogData, error) {
+	var result = make([]*LogData, 0)
+	var res struct {
+		LogServices *OData `json:"LogServices"`
+	}
+
+	err := redfishGet(r, src.Endpoint, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.LogServices == nil || res.LogServices.ID == nil {
+		return result, nil
+	}
+
+	members, err := collectionMembers(r, *res.LogServices.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, member := range members {
+		var svc logServiceResource
+
+		err = redfishGet(r, member, &svc)
+		if err != nil {
+			return nil, err
+		}
+
+		if logID != "" && (svc.ID == nil || *svc.ID != logID) {
+			continue
+		}
+
+		ep := member
+		logData := LogData{
+			SourceType:   src.Type,
+			SourceID:     src.ID,
+			ID:           svc.ID,
+			Name:         svc.Name,
+			Entries:      make([]LogEntryData, 0),
+			Status:       svc.Status,
+			SelfEndpoint: &ep,
+		}
+		if svc.Actions.ClearLog != nil {
+			logData.ClearLog = svc.Actions.ClearLog.Target
+		}
+		if withEntries && svc.Entries != nil && svc.Entries.ID != nil {
+			logData.Entries, err = fetchLogEntries(r, *svc.Entries.ID)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		result = append(result, &logData)
+	}
+
+	return result, nil
+}
+
+// fetchLogEntries - all entries of a log entry collection, Members@odata.nextLink is followed
+// and entries only provided as link are read
+func fetchLogEntries(r redfish.Redfish, endpoint string) ([]LogEntryData, error) {
+	var result = make([]LogEntryData, 0)
+	var seen = make(map[string]bool)
+
+	for endpoint != "" {
+		var collection logEntryCollection
+
+		err := redfishGet(r, endpoint, &collection)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, member := range collection.Members {
+			entry := member.LogEntryData
+			entry.SelfEndpoint = member.OData.ID
+
+			if entry.Created == nil && entry.Message == nil && entry.SelfEndpoint != nil {
+				ep := *entry.SelfEndpoint
+				err = redfishGet(r, ep, &entry)
+				if err != nil {
+					return nil, err
+				}
+				entry.SelfEndpoint = &ep
+			}
+
+			result = append(result, entry)
+		}
+
+		endpoint = ""
+		if collection.NextLink != nil && !seen[*collection.NextLink] {
+			endpoint = *collection.NextLink
+			seen[endpoint] = true
+		}
+	}
+
+	return result, nil
+}
+
+// filterLogEntries - entries with at least severity minSeverity created at or after since,
+// entries with an unparsable timestamp are kept
+func filterLogEntries(entries []LogEntryData, minSeverity string, since time.Time) []LogEntryData {
+	var result = make([]LogEntryData, 0, len(entries))
+
+	for _, entry := range entries {
+		if minSeverity != "" && logSeverity[strings.ToLower(csvString(entry.Severity))] < logSeverity[strings.ToLower(minSeverity)] {
+			continue
+		}
+
+		if !since.IsZero() && entry.Created != nil {
+			created, err := time.Parse(time.RFC3339, *entry.Created)
+			if err == nil && created.Before(since) {
+				continue
+			}
+		}
+
+		result = append(result, entry)
+	}
+
+	return result
+}
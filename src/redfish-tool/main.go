@@ -3,6 +3,7 @@ package main
 import (
 	"flag"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"strings"
 	"syscall"
@@ -26,11 +27,20 @@ func main() {
 	configFile := flag.String("config", "", "Configuration file to use")
 	help := flag.Bool("help", false, "Show help text")
 	hosts := flag.String("host", "", "Hosts to work on")
+	hostsFile := flag.String("hosts-file", "", "Read hosts to work on from file")
 	port := flag.Int("port", 0, "Alternate port to connect to")
 	timeout := flag.Int64("timeout", 60, "Connection timeout in seconds")
 	verbose := flag.Bool("verbose", false, "Verbose operation")
 	version := flag.Bool("version", false, "Show version")
-	outFormat := flag.String("format", "text", "Output format (text, JSON)")
+	outFormat := flag.String("format", "text", "Output format (text, JSON, JSON array, YAML, table, CSV, template)")
+	templateText := flag.String("template", "", "Template for output format template")
+	templateFile := flag.String("template-file", "", "Read template for output format template from file")
+	sortField := flag.String("sort", "", "Sort listings by field")
+	sortReverse := flag.Bool("reverse", false, "Sort listings in reverse order")
+	fields := flag.String("fields", "", "Comma separated list of fields to print")
+	var filters filterList
+	flag.Var(&filters, "filter", "Only print items matching <field>=<value> or <field>!=<value>")
+	parallel := flag.Int("parallel", 1, "Number of hosts to work on in parallel")
 
 	// Logging setup
 	var logFmt = new(log.TextFormatter)
@@ -42,33 +52,55 @@ func main() {
 	flag.Parse()
 	if *help {
 		showUsage()
-		os.Exit(0)
+		os.Exit(ExitOK)
 	}
 
 	if *version {
 		showVersion()
-		os.Exit(0)
+		os.Exit(ExitOK)
 	}
 
 	trailing := flag.Args()
 
+	// options set on the command line take precedence over the configuration file
+	var cmdLine HostConfiguration
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "user":
+			cmdLine.User = user
+		case "password":
+			cmdLine.Password = password
+		case "port":
+			cmdLine.Port = port
+		case "timeout":
+			cmdLine.Timeout = timeout
+		case "insecure":
+			cmdLine.Insecure = insecure
+		}
+	})
+
+	if *ask {
+		fmt.Print("Password: ")
+		rawPass, _ := terminal.ReadPassword(int(syscall.Stdin))
+		pass := strings.Replace(strings.Replace(strings.Replace(string(rawPass), "\r", "", -1), "\n", "", -1), "\t", "", -1)
+		cmdLine.Password = &pass
+		fmt.Println()
+	}
+	if *passwordFile != "" {
+		_passwd, err := readSingleLine(*passwordFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: Unable to read password from file: %s\n", err.Error())
+			os.Exit(ExitFailure)
+		}
+		cmdLine.Password = &_passwd
+	}
+
+	var config *Configuration
 	if *configFile != "" {
-		// TODO: not implemented yet - read and parse configuration file
-	} else {
-		if *ask {
-			fmt.Print("Password: ")
-			rawPass, _ := terminal.ReadPassword(int(syscall.Stdin))
-			pass := strings.Replace(strings.Replace(strings.Replace(string(rawPass), "\r", "", -1), "\n", "", -1), "\t", "", -1)
-			password = &pass
-			fmt.Println()
-		}
-		if *passwordFile != "" {
-			_passwd, err := readSingleLine(*passwordFile)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error: Unable to read password from file: %s\n", err.Error())
-				os.Exit(1)
-			}
-			password = &_passwd
+		config, err = readConfigurationFile(*configFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: Unable to read configuration file: %s\n", err.Error())
+			os.Exit(ExitFailure)
 		}
 	}
 
@@ -77,163 +109,264 @@ func main() {
 		format = OutputText
 	} else if _format == "json" {
 		format = OutputJSON
+	} else if _format == "json-array" {
+		format = OutputJSONArray
+	} else if _format == "yaml" {
+		format = OutputYAML
+	} else if _format == "table" {
+		format = OutputTable
+	} else if _format == "csv" {
+		format = OutputCSV
+	} else if _format == "template" {
+		format = OutputTemplate
 	} else {
 		fmt.Fprintf(os.Stderr, "Error: Invalid output format\n\n")
 		showUsage()
-		os.Exit(1)
+		os.Exit(ExitUsage)
+	}
+
+	var opts = OutputOptions{
+		Format:      format,
+		SortField:   strings.TrimSpace(*sortField),
+		SortReverse: *sortReverse,
+		Fields:      parseFieldList(*fields),
+		Filters:     filters,
+	}
+
+	if *templateText != "" && *templateFile != "" {
+		fmt.Fprintf(os.Stderr, "Error: -template and -template-file are mutually exclusive\n\n")
+		os.Exit(ExitUsage)
+	}
+
+	if *templateText != "" || *templateFile != "" {
+		// a template implies the template output format
+		if format == OutputText {
+			format = OutputTemplate
+			opts.Format = format
+		}
+		if format != OutputTemplate {
+			fmt.Fprintf(os.Stderr, "Error: -template and -template-file require output format template\n\n")
+			os.Exit(ExitUsage)
+		}
+
+		name := "template"
+		text := *templateText
+		if *templateFile != "" {
+			raw, err := ioutil.ReadFile(*templateFile)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: Unable to read template file: %s\n", err.Error())
+				os.Exit(ExitFailure)
+			}
+			name = *templateFile
+			text = string(raw)
+		}
+
+		opts.Template, err = parseOutputTemplate(name, text)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: Invalid template: %s\n\n", err.Error())
+			os.Exit(ExitUsage)
+		}
+	} else if format == OutputTemplate {
+		fmt.Fprintf(os.Stderr, "Error: Output format template requires -template or -template-file\n\n")
+		os.Exit(ExitUsage)
 	}
 
 	// get requested command
 	if len(trailing) == 0 {
 		fmt.Fprintf(os.Stderr, "Error: No command defined\n\n")
 		showUsage()
-		os.Exit(1)
+		os.Exit(ExitUsage)
 	}
 	command := strings.ToLower(trailing[0])
 
-	if *hosts == "" {
-		fmt.Fprintf(os.Stderr, "Error: No destination host given\n\n")
-		showUsage()
-		os.Exit(1)
+	if *hostsFile == "-" && *passwordFile == "-" {
+		fmt.Fprintf(os.Stderr, "Error: -hosts-file and -password-file can't both be read from standard input\n\n")
+		os.Exit(ExitUsage)
 	}
 
-	if *user == "" || *password == "" {
-		fmt.Fprintf(os.Stderr, "Error: Missing login credentials (username and/or password)")
-		showUsage()
-		os.Exit(1)
+	var inventory *Inventory
+	if *hostsFile != "" {
+		inventory, err = readHostsFile(*hostsFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: Unable to read hosts file: %s\n", err.Error())
+			os.Exit(ExitFailure)
+		}
 	}
 
-	if *timeout < 0 {
-		fmt.Fprintf(os.Stderr, "Error: Invalid timeout %d; must be >= 0\n\n", *timeout)
-		os.Exit(2)
+	var hostList []HostEntry
+	if *hosts != "" {
+		hostList, err = expandHostList(*hosts, inventory)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s\n\n", err.Error())
+			os.Exit(ExitUsage)
+		}
+	} else if inventory != nil {
+		hostList = inventory.Hosts
 	}
 
-	hostList := strings.Split(*hosts, ",")
-	for _, host := range hostList {
-		if *verbose {
-			log.WithFields(log.Fields{
-				"hostname": host,
-			}).Info("Connecting to host")
+	if *parallel < 1 {
+		fmt.Fprintf(os.Stderr, "Error: Invalid number of parallel hosts %d; must be >= 1\n\n", *parallel)
+		os.Exit(ExitUsage)
+	}
+
+	if len(hostList) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: No destination host given\n\n")
+		showUsage()
+		os.Exit(ExitUsage)
+	}
+
+	// password files from the configuration file are only read once
+	passwordFiles := make(map[string]string)
+
+	var rfList []redfish.Redfish
+	for _, entry := range hostList {
+		host := entry.Hostname
+
+		// port and user of a single host take precedence over all other settings
+		hcfg := buildHostConfiguration(host, config, cmdLine)
+		hcfg = mergeHostConfiguration(hcfg, HostConfiguration{
+			User: entry.User,
+			Port: entry.Port,
+		})
+
+		if hcfg.PasswordFile != nil {
+			_passwd, found := passwordFiles[*hcfg.PasswordFile]
+			if !found {
+				_passwd, err = readSingleLine(*hcfg.PasswordFile)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error: Unable to read password for %s from file: %s\n", host, err.Error())
+					os.Exit(ExitFailure)
+				}
+				passwordFiles[*hcfg.PasswordFile] = _passwd
+			}
+			hcfg.Password = &_passwd
+		}
+
+		if hcfg.User == nil || *hcfg.User == "" || hcfg.Password == nil || *hcfg.Password == "" {
+			fmt.Fprintf(os.Stderr, "Error: Missing login credentials (username and/or password) for %s\n\n", host)
+			showUsage()
+			os.Exit(ExitUsage)
+		}
+
+		if hcfg.Port == nil {
+			hcfg.Port = port
 		}
 
-		rf := redfish.Redfish{
+		if hcfg.Timeout == nil {
+			hcfg.Timeout = timeout
+		}
+		if *hcfg.Timeout < 0 {
+			fmt.Fprintf(os.Stderr, "Error: Invalid timeout %d for %s; must be >= 0\n\n", *hcfg.Timeout, host)
+			os.Exit(ExitUsage)
+		}
+
+		if hcfg.Insecure == nil {
+			hcfg.Insecure = insecure
+		}
+
+		rfList = append(rfList, redfish.Redfish{
 			Hostname:    host,
-			Port:        *port,
-			Username:    *user,
-			Password:    *password,
-			InsecureSSL: *insecure,
+			Port:        *hcfg.Port,
+			Username:    *hcfg.User,
+			Password:    *hcfg.Password,
+			InsecureSSL: *hcfg.Insecure,
 			Debug:       *debug,
-			Timeout:     time.Duration(*timeout) * time.Second,
+			Timeout:     time.Duration(*hcfg.Timeout) * time.Second,
 			Verbose:     *verbose,
+		})
+	}
+
+	cmd, found := commands[command]
+	if !found {
+		log.WithFields(log.Fields{
+			"command": command,
+		}).Error("Unknown command")
+		showUsage()
+		os.Exit(ExitUsage)
+	}
+
+	if (format == OutputTable || format == OutputCSV) && !tabularCommands[command] {
+		fmt.Fprintf(os.Stderr, "Error: Output format %s is not supported by command %s\n\n", _format, command)
+		os.Exit(ExitUsage)
+	}
+
+	itemType, listing := listingCommands[command]
+	if opts.SortField != "" || opts.SortReverse {
+		if !listing {
+			fmt.Fprintf(os.Stderr, "Error: -sort and -reverse are not supported by command %s\n\n", command)
+			os.Exit(ExitUsage)
 		}
 
-		if command == "get-all-users" {
-			err = getAllUsers(rf, format)
-			if err != nil {
-				log.Error(err.Error())
-			}
-		} else if command == "get-user" {
-			err = getUser(rf, trailing[1:], format)
-			if err != nil {
-				log.Error(err.Error())
-			}
-		} else if command == "get-all-roles" {
-			err = getAllRoles(rf, format)
-			if err != nil {
-				log.Error(err.Error())
-			}
-		} else if command == "get-role" {
-			err = getRole(rf, trailing[1:], format)
-			if err != nil {
-				log.Error(err.Error())
-			}
-		} else if command == "get-all-managers" {
-			err = getAllManagers(rf, format)
-			if err != nil {
-				log.Error(err.Error())
-			}
-		} else if command == "get-manager" {
-			err = getManager(rf, trailing[1:], format)
-			if err != nil {
-				log.Error(err.Error())
-			}
-		} else if command == "get-all-systems" {
-			err = getAllSystems(rf, format)
-			if err != nil {
-				log.Error(err.Error())
-			}
-		} else if command == "get-system" {
-			err = getSystem(rf, trailing[1:], format)
-			if err != nil {
-				log.Error(err.Error())
-			}
-		} else if command == "gen-csr" {
-			err = genCSR(rf, trailing[1:])
-			if err != nil {
-				log.Error(err.Error())
-			}
-		} else if command == "fetch-csr" {
-			err = fetchCSR(rf)
-			if err != nil {
-				log.Error(err.Error())
-			}
-		} else if command == "import-cert" {
-			err = importCertificate(rf, trailing[1:])
-			if err != nil {
-				log.Error(err.Error())
-			}
-		} else if command == "reset-sp" {
-			err = resetSP(rf)
-			if err != nil {
-				log.Error(err.Error())
-			}
-		} else if command == "add-user" {
-			err = addUser(rf, trailing[1:])
-			if err != nil {
-				log.Error(err.Error())
-			}
-		} else if command == "del-user" {
-			err = delUser(rf, trailing[1:])
-			if err != nil {
-				log.Error(err.Error())
-			}
-		} else if command == "modify-user" {
-			err = modifyUser(rf, trailing[1:])
-			if err != nil {
-				log.Error(err.Error())
-			}
-		} else if command == "passwd" {
-			err = passwd(rf, trailing[1:])
-			if err != nil {
-				log.Error(err.Error())
-			}
-		} else if command == "system-power" {
-			err = systemPower(rf, trailing[1:])
-			if err != nil {
-				log.Error(err.Error())
-			}
-		} else if command == "get-license" {
-			err = getLicense(rf, trailing[1:], format)
-			if err != nil {
-				log.Error(err.Error())
-			}
-		} else if command == "add-license" {
-			err = addLicense(rf, trailing[1:])
-			if err != nil {
-				log.Error(err.Error())
+		if opts.SortField != "" && !fieldExists(itemType, opts.SortField) {
+			fmt.Fprintf(os.Stderr, "Error: Unknown field %s for -sort\n\n", opts.SortField)
+			os.Exit(ExitUsage)
+		}
+	}
+
+	if len(opts.Fields) > 0 || len(opts.Filters) > 0 {
+		if !listing {
+			fmt.Fprintf(os.Stderr, "Error: -fields and -filter are not supported by command %s\n\n", command)
+			os.Exit(ExitUsage)
+		}
+
+		err = checkFieldList(itemType, opts.Fields)
+		if err == nil {
+			err = checkFilterFields(itemType, opts.Filters)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s\n\n", err.Error())
+			os.Exit(ExitUsage)
+		}
+	}
+
+	// print output of every host as soon as it and all hosts before it are finished
+	// table, CSV and JSON array output is merged for all hosts and printed at the end
+	mergeOutput := format == OutputTable || format == OutputCSV || format == OutputJSONArray
+	args := trailing[1:]
+	if prepare, found := commandPreparers[command]; found {
+		args, err = prepare(args)
+		if err != nil {
+			log.Error(err.Error())
+			if _, ok := err.(usageError); ok {
+				os.Exit(ExitUsage)
 			}
-		} else {
-			log.WithFields(log.Fields{
-				"command": command,
-			}).Error("Unknown command")
-			showUsage()
-			os.Exit(1)
+			os.Exit(ExitFailure)
+		}
+	}
+
+	var usageErr error
+	results := runOnHosts(rfList, *parallel, cmd, args, &opts)
+	for _, result := range results {
+		<-result.done
+		if _, ok := result.Err.(usageError); ok {
+			usageErr = result.Err
+			continue
+		}
+		if !mergeOutput {
+			os.Stdout.Write(result.Output.Bytes())
 		}
+		if result.Err != nil {
+			log.Error(result.Err.Error())
+		}
+	}
+
+	// invalid options of the command are the same for all hosts
+	if usageErr != nil {
+		log.Error(usageErr.Error())
+		os.Exit(ExitUsage)
 	}
 
+	if format == OutputTable || format == OutputCSV {
+		err = renderTabular(results, format, os.Stdout)
+	} else if format == OutputJSONArray {
+		err = renderJSONArray(results, command, os.Stdout)
+	}
 	if err != nil {
-		os.Exit(1)
-	} else {
-		os.Exit(0)
+		log.Error(err.Error())
+	}
+
+	if len(results) > 1 {
+		printFailureSummary(results, format)
 	}
+	os.Exit(exitStatus(results))
 }
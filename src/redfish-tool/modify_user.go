@@ -1,17 +1,17 @@
 package main
 
 import (
-	"errors"
 	"flag"
 	"fmt"
 	redfish "git.ypbind.de/repository/go-redfish.git"
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/crypto/ssh/terminal"
+	"io"
 	"strings"
 	"syscall"
 )
 
-func modifyUser(r redfish.Redfish, args []string) error {
+func modifyUser(r redfish.Redfish, args []string, opts *OutputOptions, out io.Writer) error {
 	var acc redfish.AccountCreateData
 
 	argParse := flag.NewFlagSet("modify-user", flag.ExitOnError)
@@ -31,18 +31,20 @@ func modifyUser(r redfish.Redfish, args []string) error {
 
 	argParse.Parse(args)
 
-	fmt.Println(r.Hostname)
+	if opts.Format == OutputText {
+		fmt.Fprintln(out, r.Hostname)
+	}
 
 	if *enable && *disable {
-		return errors.New("ERROR: -enable and -disable are mutually exclusive")
+		return usageError("ERROR: -enable and -disable are mutually exclusive")
 	}
 
 	if *password != "" && *passwordFile != "" {
-		return errors.New("ERROR: -password and -password-file are mutually exclusive")
+		return usageError("ERROR: -password and -password-file are mutually exclusive")
 	}
 
 	if (*password != "" || *passwordFile != "") && *askPassword {
-		return errors.New("ERROR: -password/-password-file and -ask-password are mutually exclusive")
+		return usageError("ERROR: -password/-password-file and -ask-password are mutually exclusive")
 	}
 
 	if *enable {
@@ -55,7 +57,7 @@ func modifyUser(r redfish.Redfish, args []string) error {
 	}
 
 	if *lock && *unlock {
-		return errors.New("ERROR: -lock and -unlock are mutually exclusive")
+		return usageError("ERROR: -lock and -unlock are mutually exclusive")
 	}
 
 	if *lock {
@@ -68,11 +70,11 @@ func modifyUser(r redfish.Redfish, args []string) error {
 	}
 
 	if *name == "" {
-		return errors.New("ERROR: Required options -name not found")
+		return usageError("ERROR: Required options -name not found")
 	}
 
 	if *password != "" && *askPassword {
-		return errors.New("ERROR: -password and -ask-password are mutually exclusive")
+		return usageError("ERROR: -password and -ask-password are mutually exclusive")
 	}
 
 	// Initialize session
@@ -125,6 +127,7 @@ func modifyUser(r redfish.Redfish, args []string) error {
 
 	// ask for password ?
 	if *askPassword {
+		terminalLock.Lock()
 		fmt.Printf("Password for %s: ", *name)
 		rawPass, _ := terminal.ReadPassword(int(syscall.Stdin))
 		pass1 := strings.Replace(strings.Replace(strings.Replace(string(rawPass), "\r", "", -1), "\n", "", -1), "\t", "", -1)
@@ -134,6 +137,7 @@ func modifyUser(r redfish.Redfish, args []string) error {
 		rawPass, _ = terminal.ReadPassword(int(syscall.Stdin))
 		pass2 := strings.Replace(strings.Replace(strings.Replace(string(rawPass), "\r", "", -1), "\n", "", -1), "\t", "", -1)
 		fmt.Println()
+		terminalLock.Unlock()
 
 		if pass1 != pass2 {
 			return fmt.Errorf("ERROR: Passwords does not match for user %s", *name)
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// csvString - value of an optional string
+func csvString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// csvBool - value of an optional boolean
+func csvBool(b *bool) string {
+	if b == nil {
+		return ""
+	}
+	return strconv.FormatBool(*b)
+}
+
+// csvFloat - value of an optional number
+func csvFloat(f *float64) string {
+	if f == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*f, 'f', -1, 64)
+}
+
+// formatCSV - CSV output with a header line, commands print this output for -format=table and -format=csv
+// and the output of all hosts is merged by renderTabular
+func formatCSV(header []string, rows [][]string) string {
+	var buffer bytes.Buffer
+
+	w := csv.NewWriter(&buffer)
+	w.Write(header)
+	w.WriteAll(rows)
+
+	// Should NEVER happen!
+	if w.Error() != nil {
+		log.Panic(w.Error())
+	}
+
+	return buffer.String()
+}
+
+// renderTabular - merge CSV output of all hosts, the header is only printed once
+func renderTabular(results []*hostResult, format uint, out io.Writer) error {
+	var header []string
+	var rows [][]string
+
+	for _, result := range results {
+		// output of failed hosts can be incomplete
+		if result.Err != nil {
+			continue
+		}
+
+		records, err := csv.NewReader(&result.Output).ReadAll()
+		if err != nil {
+			return err
+		}
+		if len(records) == 0 {
+			continue
+		}
+
+		if header == nil {
+			header = records[0]
+		}
+		rows = append(rows, records[1:]...)
+	}
+
+	if header == nil {
+		return nil
+	}
+
+	if format == OutputCSV {
+		_, err := io.WriteString(out, formatCSV(header, rows))
+		return err
+	}
+
+	tw := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	tw.Write([]byte(strings.Join(header, "\t") + "\n"))
+	for _, row := range rows {
+		for i := range row {
+			if row[i] == "" {
+				row[i] = "-"
+			}
+		}
+		tw.Write([]byte(strings.Join(row, "\t") + "\n"))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	redfish "git.ypbind.de/repository/go-redfish.git"
+	log "github.com/sirupsen/logrus"
+)
+
+// FieldFilter - filter expression <field>=<value> or <field>!=<value> set by -filter
+type FieldFilter struct {
+	Field  string
+	Value  string
+	Negate bool
+}
+
+// filterList - list of filter expressions, -filter can be used multiple times
+type filterList []FieldFilter
+
+func (f *filterList) String() string {
+	var result []string
+
+	for _, flt := range *f {
+		if flt.Negate {
+			result = append(result, flt.Field+"!="+flt.Value)
+		} else {
+			result = append(result, flt.Field+"="+flt.Value)
+		}
+	}
+
+	return strings.Join(result, ",")
+}
+
+// Set - parse a filter expression, called by the flag package for every -filter option
+func (f *filterList) Set(s string) error {
+	var flt FieldFilter
+
+	i := strings.Index(s, "=")
+	if i <= 0 {
+		return fmt.Errorf("ERROR: Invalid filter expression %s", s)
+	}
+
+	flt.Field = s[:i]
+	flt.Value = strings.TrimSpace(s[i+1:])
+	if strings.HasSuffix(flt.Field, "!") {
+		flt.Field = strings.TrimSuffix(flt.Field, "!")
+		flt.Negate = true
+	}
+
+	flt.Field = strings.TrimSpace(flt.Field)
+	if flt.Field == "" {
+		return fmt.Errorf("ERROR: Invalid filter expression %s", s)
+	}
+
+	*f = append(*f, flt)
+	return nil
+}
+
+// parseFieldList - comma separated list of field names set by -fields
+func parseFieldList(s string) []string {
+	var result []string
+
+	for _, f := range strings.Split(s, ",") {
+		f = strings.TrimSpace(f)
+		if f != "" {
+			result = append(result, f)
+		}
+	}
+
+	return result
+}
+
+// checkFieldList - check the field names set by -fields against the type of the listed items
+func checkFieldList(t reflect.Type, fields []string) error {
+	for _, name := range fields {
+		if !fieldExists(t, name) {
+			return fmt.Errorf("Unknown field %s in field list", name)
+		}
+	}
+
+	return nil
+}
+
+// checkFilterFields - check the field names of all filter expressions against the type of the listed items
+func checkFilterFields(t reflect.Type, filters []FieldFilter) error {
+	for _, flt := range filters {
+		if !fieldExists(t, flt.Field) {
+			return fmt.Errorf("Unknown field %s in filter expression", flt.Field)
+		}
+	}
+
+	return nil
+}
+
+// matchFilters - check if a Redfish item matches all filter expressions, values are compared case-insensitive
+// The field names must have been checked by checkFilterFields
+func matchFilters(v reflect.Value, filters []FieldFilter) bool {
+	for _, flt := range filters {
+		field, _ := lookupField(v, flt.Field)
+
+		if strings.EqualFold(fieldString(field), flt.Value) == flt.Negate {
+			return false
+		}
+	}
+
+	return true
+}
+
+// selectKeys - keys of a map of Redfish items matching the filters set by -filter, sorted as set by -sort and -reverse
+func selectKeys(m interface{}, opts *OutputOptions) []string {
+	var result []string
+
+	keys := sortedKeys(m, opts)
+	if len(opts.Filters) == 0 {
+		return keys
+	}
+
+	mval := reflect.ValueOf(m)
+	for _, k := range keys {
+		if matchFilters(mval.MapIndex(reflect.ValueOf(k)), opts.Filters) {
+			result = append(result, k)
+		}
+	}
+
+	return result
+}
+
+// fieldInterface - value of a field for JSON and YAML output, nil for unset values
+func fieldInterface(v reflect.Value) interface{} {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	if !v.IsValid() {
+		return nil
+	}
+
+	return v.Interface()
+}
+
+// printSelectedFields - print only the fields set by -fields of the Redfish items in m
+// Templates select their fields themself, so this is used for all other output formats
+// The field names must have been checked by checkFieldList
+func printSelectedFields(r redfish.Redfish, m interface{}, keys []string, opts *OutputOptions) string {
+	var result string
+	var rows [][]string
+	var list = make([]map[string]interface{}, 0, len(keys))
+
+	mval := reflect.ValueOf(m)
+
+	for _, k := range keys {
+		var row = []string{r.Hostname}
+		var data = make(map[string]interface{})
+		var text string
+
+		for _, name := range opts.Fields {
+			field, _ := lookupField(mval.MapIndex(reflect.ValueOf(k)), name)
+
+			value := fieldString(field)
+			row = append(row, value)
+			data[name] = fieldInterface(field)
+			if value != "" {
+				text += "  " + name + ": " + value + "\n"
+			}
+		}
+
+		rows = append(rows, row)
+		list = append(list, data)
+		result += " " + k + "\n" + text
+	}
+
+	switch opts.Format {
+	case OutputTable, OutputCSV:
+		return formatCSV(append([]string{"Hostname"}, opts.Fields...), rows)
+	case OutputYAML:
+		return formatYAML(r, list)
+	case OutputJSONArray:
+		return formatJSONData(list)
+	case OutputJSON:
+		result = ""
+		for _, data := range list {
+			str, err := json.Marshal(data)
+			// Should NEVER happen!
+			if err != nil {
+				log.Panic(err)
+			}
+			result += fmt.Sprintf("{\"%s\":%s}\n", r.Hostname, string(str))
+		}
+		return result
+	}
+
+	return r.Hostname + "\n" + result
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// JSONArrayEntry - result of a command on a single host for -format=json-array
+type JSONArrayEntry struct {
+	Hostname string          `json:"hostname"`
+	Command  string          `json:"command"`
+	Success  bool            `json:"success"`
+	Error    *string         `json:"error"`
+	Data     json.RawMessage `json:"data"`
+}
+
+// formatJSONData - data without the host name, commands print this output for -format=json-array
+// and the output of all hosts is merged by renderJSONArray
+func formatJSONData(v interface{}) string {
+	str, err := json.Marshal(v)
+	// Should NEVER happen!
+	if err != nil {
+		log.Panic(err)
+	}
+
+	return string(str)
+}
+
+// renderJSONArray - merge output of all hosts into a single JSON array
+func renderJSONArray(results []*hostResult, command string, out io.Writer) error {
+	var entries = make([]JSONArrayEntry, 0, len(results))
+
+	for _, result := range results {
+		var entry = JSONArrayEntry{
+			Hostname: result.Hostname,
+			Command:  command,
+			Success:  result.Err == nil,
+			Data:     json.RawMessage("null"),
+		}
+
+		if result.Err != nil {
+			msg := result.Err.Error()
+			entry.Error = &msg
+		} else {
+			data := bytes.TrimSpace(result.Output.Bytes())
+			if len(data) > 0 {
+				if json.Valid(data) {
+					entry.Data = json.RawMessage(data)
+				} else {
+					// commands without JSON output
+					entry.Data = json.RawMessage(formatJSONData(string(data)))
+				}
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	str, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	_, err = out.Write(append(str, '\n'))
+	return err
+}
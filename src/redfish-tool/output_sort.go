@@ -0,0 +1,154 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// lookupField - value of the (optionally nested, separated by dots) field name of v
+// Field names are matched case-insensitive against the name of the structure field and its JSON name
+func lookupField(v reflect.Value, name string) (reflect.Value, bool) {
+	for _, part := range strings.Split(name, ".") {
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return reflect.Value{}, true
+			}
+			v = v.Elem()
+		}
+
+		if v.Kind() != reflect.Struct {
+			return reflect.Value{}, false
+		}
+
+		var found bool
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+
+			jsonName := strings.Split(field.Tag.Get("json"), ",")[0]
+			if strings.EqualFold(field.Name, part) || (jsonName != "" && strings.EqualFold(jsonName, part)) {
+				v = v.Field(i)
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return reflect.Value{}, false
+		}
+	}
+
+	return v, true
+}
+
+// fieldExists - check if the (optionally nested, separated by dots) field name exists in type t,
+// names are matched like lookupField does
+func fieldExists(t reflect.Type, name string) bool {
+	for _, part := range strings.Split(name, ".") {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+
+		// the type of the value is only known at runtime
+		if t.Kind() == reflect.Interface {
+			return true
+		}
+
+		if t.Kind() != reflect.Struct {
+			return false
+		}
+
+		var found bool
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+
+			jsonName := strings.Split(field.Tag.Get("json"), ",")[0]
+			if strings.EqualFold(field.Name, part) || (jsonName != "" && strings.EqualFold(jsonName, part)) {
+				t = field.Type
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// fieldString - string representation of a field value, empty for unset values
+func fieldString(v reflect.Value) string {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+
+	if !v.IsValid() {
+		return ""
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Struct, reflect.Map, reflect.Slice:
+		return formatJSONData(v.Interface())
+	default:
+		return fmt.Sprintf("%v", v.Interface())
+	}
+}
+
+// lessNatural - compare numerically if both values are numbers, e.g. for account IDs 2 < 10
+func lessNatural(a string, b string) bool {
+	na, erra := strconv.ParseFloat(a, 64)
+	nb, errb := strconv.ParseFloat(b, 64)
+	if erra == nil && errb == nil {
+		return na < nb
+	}
+	return a < b
+}
+
+// sortedKeys - keys of a map of Redfish items, sorted by the field set by -sort or by the keys itself
+// and in reverse order if -reverse is set
+func sortedKeys(m interface{}, opts *OutputOptions) []string {
+	var keys []string
+	var values = make(map[string]string)
+
+	mval := reflect.ValueOf(m)
+	for _, k := range mval.MapKeys() {
+		keys = append(keys, k.String())
+		values[k.String()] = k.String()
+	}
+
+	if opts.SortField != "" {
+		for _, k := range mval.MapKeys() {
+			// the field name has been checked by fieldExists
+			field, _ := lookupField(mval.MapIndex(k), opts.SortField)
+			values[k.String()] = fieldString(field)
+		}
+	}
+
+	sort.SliceStable(keys, func(i int, j int) bool {
+		a, b := values[keys[i]], values[keys[j]]
+		if a == b {
+			return lessNatural(keys[i], keys[j])
+		}
+		if opts.SortReverse {
+			return lessNatural(b, a)
+		}
+		return lessNatural(a, b)
+	})
+
+	return keys
+}
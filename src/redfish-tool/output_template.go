@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+
+	redfish "git.ypbind.de/repository/go-redfish.git"
+)
+
+// templateFuncs - additional functions available in user-defined templates
+var templateFuncs = template.FuncMap{
+	"join": strings.Join,
+}
+
+func parseOutputTemplate(name string, text string) (*template.Template, error) {
+	return template.New(name).Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+}
+
+// templateData - fields of v for the template, the host name is available as .Host
+// Data other than structures (e.g. a CSR) is available as .Data
+func templateData(r redfish.Redfish, v interface{}) map[string]interface{} {
+	var result = map[string]interface{}{
+		"Host": r.Hostname,
+	}
+
+	val := reflect.ValueOf(v)
+	for val.Kind() == reflect.Ptr && !val.IsNil() {
+		val = val.Elem()
+	}
+
+	if val.Kind() != reflect.Struct {
+		result["Data"] = v
+		return result
+	}
+
+	typ := val.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		if typ.Field(i).PkgPath != "" {
+			continue
+		}
+
+		field := val.Field(i)
+		if field.Kind() == reflect.Ptr {
+			if field.IsNil() {
+				result[typ.Field(i).Name] = ""
+				continue
+			}
+			field = field.Elem()
+		}
+		result[typ.Field(i).Name] = field.Interface()
+	}
+
+	return result
+}
+
+// formatTemplate - render every item of list using the user-defined template, one item per line
+func formatTemplate(r redfish.Redfish, opts *OutputOptions, list ...interface{}) (string, error) {
+	var result string
+
+	for _, v := range list {
+		var buffer bytes.Buffer
+
+		err := opts.Template.Execute(&buffer, templateData(r, v))
+		if err != nil {
+			return "", fmt.Errorf("ERROR: Template execution failed for %s: %s", r.Hostname, err.Error())
+		}
+
+		result += buffer.String()
+		if !strings.HasSuffix(result, "\n") {
+			result += "\n"
+		}
+	}
+
+	return result, nil
+}
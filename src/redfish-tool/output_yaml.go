@@ -0,0 +1,29 @@
+package main
+
+import (
+	"encoding/json"
+
+	redfish "git.ypbind.de/repository/go-redfish.git"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+)
+
+// formatYAML - data as YAML mapping with the host name as key, the output of all hosts forms a single YAML document
+// Data is converted using its JSON representation to keep the names of the Redfish attributes
+func formatYAML(r redfish.Redfish, v interface{}) string {
+	var data interface{}
+
+	err := json.Unmarshal([]byte(formatJSONData(v)), &data)
+	// Should NEVER happen!
+	if err != nil {
+		log.Panic(err)
+	}
+
+	str, err := yaml.Marshal(map[string]interface{}{r.Hostname: data})
+	// Should NEVER happen!
+	if err != nil {
+		log.Panic(err)
+	}
+
+	return string(str)
+}
@@ -1,16 +1,16 @@
 package main
 
 import (
-	"errors"
 	"flag"
 	"fmt"
 	redfish "git.ypbind.de/repository/go-redfish.git"
 	"golang.org/x/crypto/ssh/terminal"
+	"io"
 	"strings"
 	"syscall"
 )
 
-func passwd(r redfish.Redfish, args []string) error {
+func passwd(r redfish.Redfish, args []string, opts *OutputOptions, out io.Writer) error {
 	argParse := flag.NewFlagSet("passwd", flag.ExitOnError)
 
 	var name = argParse.String("name", "", "Name of user account")
@@ -19,14 +19,16 @@ func passwd(r redfish.Redfish, args []string) error {
 
 	argParse.Parse(args)
 
-	fmt.Println(r.Hostname)
+	if opts.Format == OutputText {
+		fmt.Fprintln(out, r.Hostname)
+	}
 
 	if *name == "" {
-		return errors.New("ERROR: Required options -name not found")
+		return usageError("ERROR: Required options -name not found")
 	}
 
 	if *password != "" && *passwordFile != "" {
-		return fmt.Errorf("ERROR: -password and -password-file are mutually exclusive")
+		return usageError("ERROR: -password and -password-file are mutually exclusive")
 	}
 
 	// Initialize session
@@ -51,6 +53,7 @@ func passwd(r redfish.Redfish, args []string) error {
 	// ask for password ?
 	if *password == "" {
 		if *passwordFile == "" {
+			terminalLock.Lock()
 			fmt.Printf("Password for %s: ", *name)
 			rawPass, _ := terminal.ReadPassword(int(syscall.Stdin))
 			fmt.Println()
@@ -60,6 +63,7 @@ func passwd(r redfish.Redfish, args []string) error {
 			rawPass, _ = terminal.ReadPassword(int(syscall.Stdin))
 			fmt.Println()
 			pass2 := strings.Replace(strings.Replace(strings.Replace(string(rawPass), "\r", "", -1), "\n", "", -1), "\t", "", -1)
+			terminalLock.Unlock()
 
 			if pass1 != pass2 {
 				return fmt.Errorf("ERROR: Passwords does not match for user %s", *name)
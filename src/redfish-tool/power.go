@@ -0,0 +1,209 @@
+package main
+
+import (
+	redfish "git.ypbind.de/repository/go-redfish.git"
+)
+
+// PowerMetricsData - power consumption over an interval
+type PowerMetricsData struct {
+	IntervalInMin        *float64 `json:"IntervalInMin"`
+	MinConsumedWatts     *float64 `json:"MinConsumedWatts"`
+	MaxConsumedWatts     *float64 `json:"MaxConsumedWatts"`
+	AverageConsumedWatts *float64 `json:"AverageConsumedWatts"`
+}
+
+// PowerLimitData - power limit (capping) of a chassis
+type PowerLimitData struct {
+	LimitInWatts   *float64 `json:"LimitInWatts"`
+	LimitException *string  `json:"LimitException"`
+	CorrectionInMs *float64 `json:"CorrectionInMs"`
+}
+
+// PowerControlData - power consumption and limit from PowerControl of the Power resource
+type PowerControlData struct {
+	MemberID           *string          `json:"MemberId"`
+	Name               *string          `json:"Name"`
+	PowerConsumedWatts *float64         `json:"PowerConsumedWatts"`
+	PowerCapacityWatts *float64         `json:"PowerCapacityWatts"`
+	PowerMetrics       PowerMetricsData `json:"PowerMetrics"`
+	PowerLimit         PowerLimitData   `json:"PowerLimit"`
+	Status             redfish.Status   `json:"Status"`
+}
+
+// PowerSupplyData - power supply unit
+type PowerSupplyData struct {
+	MemberID             *string        `json:"MemberId"`
+	Name                 *string        `json:"Name"`
+	Model                *string        `json:"Model"`
+	Manufacturer         *string        `json:"Manufacturer"`
+	SerialNumber         *string        `json:"SerialNumber"`
+	FirmwareVersion      *string        `json:"FirmwareVersion"`
+	PowerSupplyType      *string        `json:"PowerSupplyType"`
+	LineInputVoltage     *float64       `json:"LineInputVoltage"`
+	PowerCapacityWatts   *float64       `json:"PowerCapacityWatts"`
+	LastPowerOutputWatts *float64       `json:"LastPowerOutputWatts"`
+	Status               redfish.Status `json:"Status"`
+}
+
+// RedundancyData - redundancy group of power supplies
+type RedundancyData struct {
+	Name            *string        `json:"Name"`
+	Mode            *string        `json:"Mode"`
+	MinNumNeeded    *float64       `json:"MinNumNeeded"`
+	MaxNumSupported *float64       `json:"MaxNumSupported"`
+	Status          redfish.Status `json:"Status"`
+}
+
+// PowerData - power supplies and power consumption of a chassis
+type PowerData struct {
+	ChassisID     string             `json:"ChassisId"`
+	PowerControl  []PowerControlData `json:"PowerControl"`
+	PowerSupplies []PowerSupplyData  `json:"PowerSupplies"`
+	Redundancy    []RedundancyData   `json:"Redundancy"`
+	SelfEndpoint  *string
+}
+
+type powerResource struct {
+	PowerControl  []PowerControlData `json:"PowerControl"`
+	PowerSupplies []PowerSupplyData  `json:"PowerSupplies"`
+	Redundancy    []RedundancyData   `json:"Redundancy"`
+}
+
+type powerSubsystemResource struct {
+	PowerSupplies         *OData `json:"PowerSupplies"`
+	PowerSupplyRedundancy []struct {
+		RedundancyType      *string        `json:"RedundancyType"`
+		MinNeededInGroup    *float64       `json:"MinNeededInGroup"`
+		MaxSupportedInGroup *float64       `json:"MaxSupportedInGroup"`
+		Status              redfish.Status `json:"Status"`
+	} `json:"PowerSupplyRedundancy"`
+}
+
+type powerSupplyResource struct {
+	ID                 *string        `json:"Id"`
+	Name               *string        `json:"Name"`
+	Model              *string        `json:"Model"`
+	Manufacturer       *string        `json:"Manufacturer"`
+	SerialNumber       *string        `json:"SerialNumber"`
+	FirmwareVersion    *string        `json:"FirmwareVersion"`
+	PowerSupplyType    *string        `json:"PowerSupplyType"`
+	PowerCapacityWatts *float64       `json:"PowerCapacityWatts"`
+	Metrics            *OData         `json:"Metrics"`
+	Status             redfish.Status `json:"Status"`
+}
+
+type powerSupplyMetricsResource struct {
+	InputVoltage     *readingValue `json:"InputVoltage"`
+	OutputPowerWatts *readingValue `json:"OutputPowerWatts"`
+}
+
+type environmentMetricsResource struct {
+	PowerWatts *readingValue `json:"PowerWatts"`
+}
+
+// fetchPower - power supplies and power consumption of a chassis from the Power resource
+// or from PowerSubsystem (and EnvironmentMetrics for the power consumption) if Power is not provided
+func fetchPower(r redfish.Redfish, chassis *ChassisData) (*PowerData, error) {
+	var result = PowerData{
+		ChassisID:     *chassis.ID,
+		PowerControl:  make([]PowerControlData, 0),
+		PowerSupplies: make([]PowerSupplyData, 0),
+		Redundancy:    make([]RedundancyData, 0),
+	}
+
+	if chassis.Power != nil && chassis.Power.ID != nil {
+		var power powerResource
+
+		err := redfishGet(r, *chassis.Power.ID, &power)
+		if err != nil {
+			return nil, err
+		}
+
+		result.PowerControl = append(result.PowerControl, power.PowerControl...)
+		result.PowerSupplies = append(result.PowerSupplies, power.PowerSupplies...)
+		result.Redundancy = append(result.Redundancy, power.Redundancy...)
+		result.SelfEndpoint = chassis.Power.ID
+
+		return &result, nil
+	}
+
+	if chassis.PowerSubsystem == nil || chassis.PowerSubsystem.ID == nil {
+		return nil, nil
+	}
+
+	var subsys powerSubsystemResource
+	err := redfishGet(r, *chassis.PowerSubsystem.ID, &subsys)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, red := range subsys.PowerSupplyRedundancy {
+		result.Redundancy = append(result.Redundancy, RedundancyData{
+			Mode:            red.RedundancyType,
+			MinNumNeeded:    red.MinNeededInGroup,
+			MaxNumSupported: red.MaxSupportedInGroup,
+			Status:          red.Status,
+		})
+	}
+
+	if subsys.PowerSupplies != nil && subsys.PowerSupplies.ID != nil {
+		members, err := collectionMembers(r, *subsys.PowerSupplies.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, member := range members {
+			var psu powerSupplyResource
+
+			err = redfishGet(r, member, &psu)
+			if err != nil {
+				return nil, err
+			}
+
+			data := PowerSupplyData{
+				MemberID:           psu.ID,
+				Name:               psu.Name,
+				Model:              psu.Model,
+				Manufacturer:       psu.Manufacturer,
+				SerialNumber:       psu.SerialNumber,
+				FirmwareVersion:    psu.FirmwareVersion,
+				PowerSupplyType:    psu.PowerSupplyType,
+				PowerCapacityWatts: psu.PowerCapacityWatts,
+				Status:             psu.Status,
+			}
+
+			if psu.Metrics != nil && psu.Metrics.ID != nil {
+				var metrics powerSupplyMetricsResource
+
+				err = redfishGet(r, *psu.Metrics.ID, &metrics)
+				if err != nil {
+					return nil, err
+				}
+				data.LineInputVoltage = readingOf(metrics.InputVoltage)
+				data.LastPowerOutputWatts = readingOf(metrics.OutputPowerWatts)
+			}
+
+			result.PowerSupplies = append(result.PowerSupplies, data)
+		}
+	}
+
+	// PowerSubsystem provides no power consumption, it is reported by EnvironmentMetrics of the chassis
+	if chassis.EnvironmentMetrics != nil && chassis.EnvironmentMetrics.ID != nil {
+		var env environmentMetricsResource
+
+		err = redfishGet(r, *chassis.EnvironmentMetrics.ID, &env)
+		if err != nil {
+			return nil, err
+		}
+
+		if env.PowerWatts != nil {
+			result.PowerControl = append(result.PowerControl, PowerControlData{
+				Name:               chassis.Name,
+				PowerConsumedWatts: env.PowerWatts.Reading,
+			})
+		}
+	}
+
+	result.SelfEndpoint = chassis.PowerSubsystem.ID
+	return &result, nil
+}
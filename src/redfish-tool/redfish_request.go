@@ -0,0 +1,254 @@
+package main
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	redfish "git.ypbind.de/repository/go-redfish.git"
+	log "github.com/sirupsen/logrus"
+)
+
+// HTTPResult - result of a request to a Redfish endpoint
+type HTTPResult struct {
+	URL        string
+	StatusCode int
+	Status     string
+	Header     http.Header
+	Content    []byte
+}
+
+// OData - reference to a Redfish resource
+type OData struct {
+	ID *string `json:"@odata.id"`
+}
+
+// collectionData - Redfish collection, some vendors (e.g. Inspur) return an array of strings instead of
+// an array of OData objects as members
+type collectionData struct {
+	Members  []json.RawMessage `json:"Members"`
+	NextLink *string           `json:"Members@odata.nextLink"`
+}
+
+// redfishURL - URL of a Redfish endpoint on host r
+func redfishURL(r redfish.Redfish, endpoint string) string {
+	if strings.HasPrefix(endpoint, "https://") || strings.HasPrefix(endpoint, "http://") {
+		return endpoint
+	}
+
+	host := r.Hostname
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	if r.Port > 0 && r.Port != 443 {
+		host = net.JoinHostPort(r.Hostname, strconv.Itoa(r.Port))
+	}
+
+	if !strings.HasPrefix(endpoint, "/") {
+		endpoint = "/" + endpoint
+	}
+
+	return "https://" + host + endpoint
+}
+
+// redfishRequest - send a request to a Redfish endpoint not covered by go-redfish, a valid session (Login) is required
+func redfishRequest(r redfish.Redfish, method string, endpoint string, contentType string, body []byte) (HTTPResult, error) {
+	requestURL := redfishURL(r, endpoint)
+
+	if r.Debug {
+		log.WithFields(log.Fields{
+			"hostname": r.Hostname,
+			"method":   method,
+			"url":      requestURL,
+			"body":     string(body),
+		}).Info("Sending request")
+	}
+
+	request, err := http.NewRequest(method, requestURL, bytes.NewReader(body))
+	if err != nil {
+		return HTTPResult{URL: requestURL}, err
+	}
+
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+
+	return doRequest(r, request, r.Timeout)
+}
+
+// redfishUpload - send length bytes read from body (e.g. a firmware image) to a Redfish endpoint without reading
+// it into memory, timeout limits the whole request including the upload, 0 for no limit
+func redfishUpload(r redfish.Redfish, method string, endpoint string, contentType string, body io.Reader, length int64, timeout time.Duration) (HTTPResult, error) {
+	requestURL := redfishURL(r, endpoint)
+
+	if r.Debug {
+		log.WithFields(log.Fields{
+			"hostname": r.Hostname,
+			"method":   method,
+			"url":      requestURL,
+			"length":   length,
+		}).Info("Uploading data")
+	}
+
+	request, err := http.NewRequest(method, requestURL, body)
+	if err != nil {
+		return HTTPResult{URL: requestURL}, err
+	}
+
+	request.ContentLength = length
+	request.Header.Set("Content-Type", contentType)
+
+	return doRequest(r, request, timeout)
+}
+
+// doRequest - send the request to host r and read the reply, timeout limits the whole request, 0 for no limit
+func doRequest(r redfish.Redfish, request *http.Request, timeout time.Duration) (HTTPResult, error) {
+	var result HTTPResult
+	var transport = &http.Transport{
+		TLSClientConfig: &tls.Config{},
+	}
+
+	if r.InsecureSSL {
+		transport.TLSClientConfig.InsecureSkipVerify = true
+	}
+
+	client := &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+	}
+
+	result.URL = request.URL.String()
+
+	request.Header.Set("Accept", "application/json")
+	if r.AuthToken != nil && *r.AuthToken != "" {
+		request.Header.Set("X-Auth-Token", *r.AuthToken)
+	}
+	request.Close = true
+
+	response, err := client.Do(request)
+	if err != nil {
+		return result, err
+	}
+	defer response.Body.Close()
+
+	result.StatusCode = response.StatusCode
+	result.Status = response.Status
+	result.Header = response.Header
+
+	result.Content, err = ioutil.ReadAll(response.Body)
+	if err != nil {
+		return result, err
+	}
+
+	if r.Debug {
+		log.WithFields(log.Fields{
+			"hostname": r.Hostname,
+			"url":      result.URL,
+			"status":   result.Status,
+			"content":  string(result.Content),
+		}).Info("Received reply")
+	}
+
+	return result, nil
+}
+
+// redfishGet - GET a Redfish endpoint and decode the JSON reply into v
+func redfishGet(r redfish.Redfish, endpoint string, v interface{}) error {
+	result, err := redfishRequest(r, "GET", endpoint, "", nil)
+	if err != nil {
+		return err
+	}
+
+	if result.StatusCode != http.StatusOK {
+		return fmt.Errorf("ERROR: HTTP GET for %s returned \"%s\" instead of \"200 OK\"", result.URL, result.Status)
+	}
+
+	return json.Unmarshal(result.Content, v)
+}
+
+// redfishSend - send the JSON encoded payload to a Redfish endpoint using method (POST, PATCH, ...)
+func redfishSend(r redfish.Redfish, method string, endpoint string, payload interface{}) (HTTPResult, error) {
+	raw, err := json.Marshal(payload)
+	// Should NEVER happen!
+	if err != nil {
+		log.Panic(err)
+	}
+
+	result, err := redfishRequest(r, method, endpoint, "application/json", raw)
+	if err != nil {
+		return result, err
+	}
+
+	if result.StatusCode < 200 || result.StatusCode > 299 {
+		return result, fmt.Errorf("ERROR: HTTP %s for %s returned \"%s\"", method, result.URL, result.Status)
+	}
+
+	return result, nil
+}
+
+// serviceEndpoint - endpoint of a top level service (e.g. Chassis) from the service root
+func serviceEndpoint(r redfish.Redfish, service string) (string, error) {
+	var root map[string]json.RawMessage
+	var link OData
+
+	err := redfishGet(r, "/redfish/v1/", &root)
+	if err != nil {
+		return "", err
+	}
+
+	raw, found := root[service]
+	if !found {
+		return "", fmt.Errorf("ERROR: %s is not provided by %s", service, r.Hostname)
+	}
+
+	err = json.Unmarshal(raw, &link)
+	if err != nil || link.ID == nil {
+		return "", fmt.Errorf("ERROR: No endpoint for %s found on %s", service, r.Hostname)
+	}
+
+	return *link.ID, nil
+}
+
+// collectionMembers - endpoints of all members of a Redfish collection, Members@odata.nextLink is followed
+func collectionMembers(r redfish.Redfish, endpoint string) ([]string, error) {
+	var result []string
+	var seen = make(map[string]bool)
+
+	for endpoint != "" {
+		var collection collectionData
+
+		err := redfishGet(r, endpoint, &collection)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, raw := range collection.Members {
+			var link OData
+			var member string
+
+			if json.Unmarshal(raw, &link) == nil && link.ID != nil {
+				member = *link.ID
+			} else if json.Unmarshal(raw, &member) != nil {
+				return nil, fmt.Errorf("ERROR: Invalid member %s in collection %s on %s", string(raw), endpoint, r.Hostname)
+			}
+
+			result = append(result, member)
+		}
+
+		endpoint = ""
+		if collection.NextLink != nil && !seen[*collection.NextLink] {
+			endpoint = *collection.NextLink
+			seen[endpoint] = true
+		}
+	}
+
+	return result, nil
+}
@@ -3,9 +3,10 @@ package main
 import (
 	"fmt"
 	redfish "git.ypbind.de/repository/go-redfish.git"
+	"io"
 )
 
-func resetSP(r redfish.Redfish) error {
+func resetSP(r redfish.Redfish, args []string, opts *OutputOptions, out io.Writer) error {
 	// Initialize session
 	err := r.Initialise()
 	if err != nil {
@@ -20,7 +21,9 @@ func resetSP(r redfish.Redfish) error {
 
 	defer r.Logout()
 
-	fmt.Println(r.Hostname)
+	if opts.Format == OutputText {
+		fmt.Fprintln(out, r.Hostname)
+	}
 
 	err = r.ResetSP()
 	if err != nil {
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+
+	redfish "git.ypbind.de/repository/go-redfish.git"
+)
+
+// resourceOwner - manager or system owning resources like log services
+type resourceOwner struct {
+	Type     string
+	ID       string
+	Endpoint string
+}
+
+// resourceOwners - managers and systems to read resources like log services from, all managers and systems if neither manager nor system is set
+func resourceOwners(r redfish.Redfish, manager string, system string) ([]resourceOwner, error) {
+	var result []resourceOwner
+
+	if system == "" {
+		mmap, err := r.MapManagersByID()
+		if err != nil {
+			return nil, err
+		}
+
+		if manager != "" {
+			if _, found := mmap[manager]; !found {
+				return nil, fmt.Errorf("ERROR: Manager %s not found on %s", manager, r.Hostname)
+			}
+		}
+
+		for _, id := range sortedKeys(mmap, &OutputOptions{}) {
+			if manager != "" && id != manager {
+				continue
+			}
+			if mmap[id].SelfEndpoint != nil {
+				result = append(result, resourceOwner{Type: "Manager", ID: id, Endpoint: *mmap[id].SelfEndpoint})
+			}
+		}
+	}
+
+	if manager == "" {
+		systems, err := selectSystems(r, system, "")
+		if err != nil {
+			return nil, err
+		}
+
+		for _, sys := range systems {
+			if sys.ID != nil && sys.SelfEndpoint != nil {
+				result = append(result, resourceOwner{Type: "System", ID: *sys.ID, Endpoint: *sys.SelfEndpoint})
+			}
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	redfish "git.ypbind.de/repository/go-redfish.git"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+func setBios(r redfish.Redfish, args []string, opts *OutputOptions, out io.Writer) error {
+	var attributes = make(map[string]interface{})
+
+	argParse := flag.NewFlagSet("set-bios", flag.ExitOnError)
+
+	var uuid = argParse.String("uuid", "", "Set BIOS attributes of system identified by UUID")
+	var id = argParse.String("id", "", "Set BIOS attributes of system identified by ID")
+	var file = argParse.String("file", "", "Read attributes from JSON file")
+	var applyTime = argParse.String("apply-time", "", "Request when the settings are applied, e.g. OnReset or Immediate")
+	var resetDefaults = argParse.Bool("reset-defaults", false, "Reset BIOS attributes to their default values")
+	var noValidate = argParse.Bool("no-validate", false, "Don't validate attributes against the attribute registry")
+
+	argParse.Parse(args)
+
+	if *uuid != "" && *id != "" {
+		return usageError("ERROR: Options -uuid and -id are mutually exclusive")
+	}
+
+	if *uuid == "" && *id == "" {
+		return usageError("ERROR: Required options -uuid or -id not found")
+	}
+
+	if *file != "" {
+		raw, err := ioutil.ReadFile(*file)
+		if err != nil {
+			return err
+		}
+
+		err = json.Unmarshal(raw, &attributes)
+		if err != nil {
+			return fmt.Errorf("ERROR: Can't parse attributes from %s: %s", *file, err.Error())
+		}
+	}
+
+	for _, kv := range argParse.Args() {
+		split := strings.SplitN(kv, "=", 2)
+		if len(split) != 2 || split[0] == "" {
+			return usageError(fmt.Sprintf("ERROR: Invalid attribute %s, attributes must be set as <name>=<value>", kv))
+		}
+		// values are converted to the type of the attribute by the validation against the registry
+		attributes[split[0]] = split[1]
+	}
+
+	if *resetDefaults && len(attributes) > 0 {
+		return usageError("ERROR: Option -reset-defaults can't be used together with attributes")
+	}
+
+	if !*resetDefaults && len(attributes) == 0 {
+		return usageError("ERROR: No attributes to set")
+	}
+
+	if *resetDefaults && *applyTime != "" {
+		return usageError("ERROR: Option -apply-time can't be used together with -reset-defaults")
+	}
+
+	// Initialize session
+	err := r.Initialise()
+	if err != nil {
+		return fmt.Errorf("ERROR: Initialisation failed for %s: %s", r.Hostname, err.Error())
+	}
+
+	// Login
+	err = r.Login()
+	if err != nil {
+		return fmt.Errorf("ERROR: Login to %s failed: %s", r.Hostname, err.Error())
+	}
+
+	defer r.Logout()
+
+	systems, err := selectSystems(r, *id, *uuid)
+	if err != nil {
+		return err
+	}
+
+	bios, err := fetchBios(r, systems[0])
+	if err != nil {
+		return err
+	}
+
+	// validate the attributes before changing anything
+	validated := attributes
+	if !*resetDefaults && !*noValidate {
+		if bios.AttributeRegistry == nil {
+			return fmt.Errorf("ERROR: No attribute registry for BIOS of system %s on %s, use -no-validate to skip validation", bios.SystemID, r.Hostname)
+		}
+
+		registry, err := fetchAttributeRegistry(r, *bios.AttributeRegistry)
+		if err != nil {
+			return fmt.Errorf("%s, use -no-validate to skip validation", err.Error())
+		}
+
+		validated, err = validateBiosAttributes(r, registry, attributes)
+		if err != nil {
+			return err
+		}
+	}
+
+	if opts.Format == OutputText {
+		fmt.Fprintln(out, r.Hostname)
+	}
+
+	if *resetDefaults {
+		return resetBios(r, bios)
+	}
+
+	return setBiosAttributes(r, bios, validated, *applyTime)
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	redfish "git.ypbind.de/repository/go-redfish.git"
+	"io"
+	"strings"
+)
+
+func setBoot(r redfish.Redfish, args []string, opts *OutputOptions, out io.Writer) error {
+	var boot = make(map[string]interface{})
+
+	argParse := flag.NewFlagSet("set-boot", flag.ExitOnError)
+
+	var uuid = argParse.String("uuid", "", "Set boot override for system identified by UUID")
+	var id = argParse.String("id", "", "Set boot override for system identified by ID")
+	var target = argParse.String("target", "", "Boot source, e.g. Pxe, Cd, Hdd, BiosSetup, UefiHttp or UefiTarget")
+	var enabled = argParse.String("enabled", "Once", "Boot override mode: Once, Continuous or Disabled")
+	var mode = argParse.String("mode", "", "Boot mode: UEFI or Legacy")
+	var uefiTarget = argParse.String("uefi-target", "", "UEFI device path to boot from if target is UefiTarget")
+	var reset = argParse.String("reset", "", "Set power state of the system after setting the boot override, e.g. ForceRestart")
+
+	argParse.Parse(args)
+
+	if *uuid != "" && *id != "" {
+		return usageError("ERROR: Options -uuid and -id are mutually exclusive")
+	}
+
+	if *uuid == "" && *id == "" {
+		return usageError("ERROR: Required options -uuid or -id not found")
+	}
+
+	_enabled := matchValue(*enabled, bootOverrideEnabled)
+	if _enabled == "" {
+		return usageError(fmt.Sprintf("ERROR: Invalid value %s for -enabled, supported values are %s", *enabled, strings.Join(bootOverrideEnabled, ", ")))
+	}
+
+	if *target == "" && _enabled != "Disabled" {
+		return usageError("ERROR: Option -target is mandatory")
+	}
+
+	if *mode != "" {
+		_mode := matchValue(*mode, bootOverrideMode)
+		if _mode == "" {
+			return usageError(fmt.Sprintf("ERROR: Invalid value %s for -mode, supported values are %s", *mode, strings.Join(bootOverrideMode, ", ")))
+		}
+		boot["BootSourceOverrideMode"] = _mode
+	}
+
+	if *uefiTarget != "" && !strings.EqualFold(*target, "UefiTarget") {
+		return usageError("ERROR: Option -uefi-target requires -target=UefiTarget")
+	}
+
+	if *uefiTarget == "" && strings.EqualFold(*target, "UefiTarget") {
+		return usageError("ERROR: Option -target=UefiTarget requires -uefi-target")
+	}
+
+	// Initialize session
+	err := r.Initialise()
+	if err != nil {
+		return fmt.Errorf("ERROR: Initialisation failed for %s: %s", r.Hostname, err.Error())
+	}
+
+	// Login
+	err = r.Login()
+	if err != nil {
+		return fmt.Errorf("ERROR: Login to %s failed: %s", r.Hostname, err.Error())
+	}
+
+	defer r.Logout()
+
+	systems, err := selectSystems(r, *id, *uuid)
+	if err != nil {
+		return err
+	}
+	sys := systems[0]
+
+	if *target != "" {
+		current, err := fetchBoot(r, sys)
+		if err != nil {
+			return err
+		}
+
+		// use the spelling of the management board and reject unsupported targets before changing anything
+		_target := *target
+		if len(current.BootSourceOverrideTargetAllowableValue) > 0 {
+			_target = matchValue(*target, current.BootSourceOverrideTargetAllowableValue)
+			if _target == "" {
+				return fmt.Errorf("ERROR: Boot source %s is not supported by %s, supported boot sources are %s", *target, r.Hostname, strings.Join(current.BootSourceOverrideTargetAllowableValue, ", "))
+			}
+		}
+		boot["BootSourceOverrideTarget"] = _target
+
+		if *uefiTarget != "" {
+			boot["UefiTargetBootSourceOverride"] = *uefiTarget
+		}
+	}
+	boot["BootSourceOverrideEnabled"] = _enabled
+
+	// reject unsupported reset types before changing the boot override
+	_reset := *reset
+	if *reset != "" {
+		resetTypes, err := fetchResetTypes(r, sys)
+		if err != nil {
+			return err
+		}
+
+		if len(resetTypes) > 0 {
+			_reset = matchValue(*reset, resetTypes)
+			if _reset == "" {
+				return fmt.Errorf("ERROR: Reset type %s is not supported by %s, supported reset types are %s", *reset, r.Hostname, strings.Join(resetTypes, ", "))
+			}
+		}
+	}
+
+	if opts.Format == OutputText {
+		fmt.Fprintln(out, r.Hostname)
+	}
+
+	err = setSystemBootOverride(r, sys, boot)
+	if err != nil {
+		return err
+	}
+
+	if _reset != "" {
+		err = r.SetSystemPowerState(sys, _reset)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	redfish "git.ypbind.de/repository/go-redfish.git"
+	log "github.com/sirupsen/logrus"
+	"io"
+	"strings"
+)
+
+func setBootOrder(r redfish.Redfish, args []string, opts *OutputOptions, out io.Writer) error {
+	var items []string
+
+	argParse := flag.NewFlagSet("set-boot-order", flag.ExitOnError)
+
+	var uuid = argParse.String("uuid", "", "Set boot order of system identified by UUID")
+	var id = argParse.String("id", "", "Set boot order of system identified by ID")
+	var order = argParse.String("order", "", "Comma separated list of boot option references, aliases or display name patterns")
+	var force = argParse.Bool("force", false, "Remove entries of the current boot order not in the new order")
+
+	argParse.Parse(args)
+
+	if *uuid != "" && *id != "" {
+		return usageError("ERROR: Options -uuid and -id are mutually exclusive")
+	}
+
+	if *uuid == "" && *id == "" {
+		return usageError("ERROR: Required options -uuid or -id not found")
+	}
+
+	for _, item := range strings.Split(*order, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+
+	if len(items) == 0 {
+		return usageError("ERROR: Option -order is mandatory")
+	}
+
+	// Initialize session
+	err := r.Initialise()
+	if err != nil {
+		return fmt.Errorf("ERROR: Initialisation failed for %s: %s", r.Hostname, err.Error())
+	}
+
+	// Login
+	err = r.Login()
+	if err != nil {
+		return fmt.Errorf("ERROR: Login to %s failed: %s", r.Hostname, err.Error())
+	}
+
+	defer r.Logout()
+
+	systems, err := selectSystems(r, *id, *uuid)
+	if err != nil {
+		return err
+	}
+
+	current, err := fetchBootOrder(r, systems[0])
+	if err != nil {
+		return err
+	}
+
+	bootOrder, dropped, err := resolveBootOrder(current, items)
+	if err != nil {
+		return err
+	}
+
+	if len(dropped) > 0 {
+		if !*force {
+			return fmt.Errorf("ERROR: New boot order of system %s on %s drops the entries %s, use -force to remove them", current.SystemID, r.Hostname, strings.Join(dropped, ", "))
+		}
+
+		if r.Verbose {
+			log.WithFields(log.Fields{
+				"hostname": r.Hostname,
+				"system":   current.SystemID,
+				"dropped":  strings.Join(dropped, ", "),
+			}).Info("Removing entries from boot order")
+		}
+	}
+
+	if opts.Format == OutputText {
+		fmt.Fprintln(out, r.Hostname)
+	}
+
+	return setSystemBootOrder(r, current, bootOrder)
+}
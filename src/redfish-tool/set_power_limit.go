@@ -0,0 +1,183 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	redfish "git.ypbind.de/repository/go-redfish.git"
+	log "github.com/sirupsen/logrus"
+	"io"
+	"strings"
+)
+
+var validLimitExceptions = []string{"NoAction", "HardPowerOff", "LogEventOnly", "Oem"}
+
+// chassisPowerControl - Power resource of a chassis, nil if the chassis provides no power control
+func chassisPowerControl(r redfish.Redfish, chassis *ChassisData) (*powerResource, error) {
+	var power powerResource
+
+	if chassis.Power == nil || chassis.Power.ID == nil {
+		return nil, nil
+	}
+
+	err := redfishGet(r, *chassis.Power.ID, &power)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(power.PowerControl) == 0 {
+		return nil, nil
+	}
+
+	return &power, nil
+}
+
+// setChassisPowerLimit - set or clear the power limit of a chassis, PowerControl entries are patched by their position in the array
+func setChassisPowerLimit(r redfish.Redfish, chassis *ChassisData, power *powerResource, member string, limit map[string]interface{}) error {
+	var index = -1
+
+	for i, pc := range power.PowerControl {
+		if member == "" || (pc.MemberID != nil && *pc.MemberID == member) {
+			index = i
+			break
+		}
+	}
+
+	if index < 0 {
+		return fmt.Errorf("ERROR: Power control %s not found for chassis %s on %s", member, *chassis.ID, r.Hostname)
+	}
+
+	// empty objects keep the other entries of the array unchanged
+	var pcList = make([]map[string]interface{}, len(power.PowerControl))
+	for i := range pcList {
+		pcList[i] = make(map[string]interface{})
+	}
+	pcList[index]["PowerLimit"] = limit
+
+	if r.Verbose {
+		log.WithFields(log.Fields{
+			"hostname":    r.Hostname,
+			"chassis":     *chassis.ID,
+			"power_limit": limit,
+		}).Info("Setting power limit")
+	}
+
+	_, err := redfishSend(r, "PATCH", *chassis.Power.ID, map[string]interface{}{"PowerControl": pcList})
+	return err
+}
+
+func setPowerLimit(r redfish.Redfish, args []string, opts *OutputOptions, out io.Writer) error {
+	var limit = make(map[string]interface{})
+	var exception string
+
+	argParse := flag.NewFlagSet("set-power-limit", flag.ExitOnError)
+
+	var id = argParse.String("id", "", "Set power limit of chassis identified by ID")
+	var all = argParse.Bool("all", false, "Set power limit of all chassis with power control")
+	var member = argParse.String("member", "", "Set power limit of power control identified by its member ID")
+	var watts = argParse.Uint("limit", 0, "Power limit in watts")
+	var _exception = argParse.String("exception", "", "Action if the power limit is exceeded")
+	var clear = argParse.Bool("clear", false, "Remove power limit")
+
+	argParse.Parse(args)
+
+	if *id != "" && *all {
+		return usageError("ERROR: Options -id and -all are mutually exclusive")
+	}
+
+	if *id == "" && !*all {
+		return usageError("ERROR: Required options -id or -all not found")
+	}
+
+	if *clear && (*watts != 0 || *_exception != "") {
+		return usageError("ERROR: Option -clear is mutually exclusive with -limit and -exception")
+	}
+
+	if !*clear && *watts == 0 {
+		return usageError("ERROR: Required options -limit or -clear not found")
+	}
+
+	if *_exception != "" {
+		for _, e := range validLimitExceptions {
+			if strings.EqualFold(e, *_exception) {
+				exception = e
+				break
+			}
+		}
+		if exception == "" {
+			return usageError(fmt.Sprintf("ERROR: Invalid limit exception %s, valid values are %s", *_exception, strings.Join(validLimitExceptions, ", ")))
+		}
+	}
+
+	if *clear {
+		limit["LimitInWatts"] = nil
+	} else {
+		limit["LimitInWatts"] = *watts
+		if exception != "" {
+			limit["LimitException"] = exception
+		}
+	}
+
+	// Initialize session
+	err := r.Initialise()
+	if err != nil {
+		return fmt.Errorf("ERROR: Initialisation failed for %s: %s", r.Hostname, err.Error())
+	}
+
+	// Login
+	err = r.Login()
+	if err != nil {
+		return fmt.Errorf("ERROR: Login to %s failed: %s", r.Hostname, err.Error())
+	}
+
+	defer r.Logout()
+
+	// check if vendor supports power capping
+	err = r.GetVendorFlavor()
+	if err != nil {
+		return err
+	}
+
+	capa, found := vendorCapabilities[r.FlavorString]
+	if found {
+		if capa&HasPowerLimit != HasPowerLimit {
+			if opts.Format == OutputText {
+				fmt.Fprintln(out, r.Hostname)
+			}
+			return errors.New("Vendor does not support setting a power limit")
+		}
+	}
+
+	clist, err := selectChassis(r, *id)
+	if err != nil {
+		return err
+	}
+
+	// -all sets the power limit of all chassis with power control
+	var count int
+	for _, chassis := range clist {
+		power, err := chassisPowerControl(r, chassis)
+		if err != nil {
+			return err
+		}
+
+		if power == nil {
+			if *id != "" {
+				return fmt.Errorf("ERROR: Chassis %s on %s provides no power control", *id, r.Hostname)
+			}
+			continue
+		}
+
+		err = setChassisPowerLimit(r, chassis, power, *member, limit)
+		if err != nil {
+			return err
+		}
+		count++
+	}
+
+	if count == 0 {
+		return fmt.Errorf("ERROR: No chassis with power control found on %s", r.Hostname)
+	}
+
+	return nil
+}
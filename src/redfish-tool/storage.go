@@ -0,0 +1,218 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	redfish "git.ypbind.de/repository/go-redfish.git"
+)
+
+// StorageControllerData - storage (e.g. RAID) controller
+type StorageControllerData struct {
+	MemberID        *string        `json:"MemberId"`
+	Name            *string        `json:"Name"`
+	Manufacturer    *string        `json:"Manufacturer"`
+	Model           *string        `json:"Model"`
+	SerialNumber    *string        `json:"SerialNumber"`
+	FirmwareVersion *string        `json:"FirmwareVersion"`
+	Status          redfish.Status `json:"Status"`
+}
+
+// VolumeData - logical drive of a storage controller
+type VolumeData struct {
+	ID            *string        `json:"Id"`
+	Name          *string        `json:"Name"`
+	RAIDType      *string        `json:"RAIDType"`
+	VolumeType    *string        `json:"VolumeType"`
+	CapacityBytes *float64       `json:"CapacityBytes"`
+	Status        redfish.Status `json:"Status"`
+	SelfEndpoint  *string
+}
+
+// DriveData - physical drive, Volumes contains the IDs of the volumes using the drive
+type DriveData struct {
+	ID                            *string        `json:"Id"`
+	Name                          *string        `json:"Name"`
+	Manufacturer                  *string        `json:"Manufacturer"`
+	Model                         *string        `json:"Model"`
+	SerialNumber                  *string        `json:"SerialNumber"`
+	MediaType                     *string        `json:"MediaType"`
+	Protocol                      *string        `json:"Protocol"`
+	CapacityBytes                 *float64       `json:"CapacityBytes"`
+	PredictedMediaLifeLeftPercent *float64       `json:"PredictedMediaLifeLeftPercent"`
+	FailurePredicted              *bool          `json:"FailurePredicted"`
+	Volumes                       []string       `json:"Volumes"`
+	Status                        redfish.Status `json:"Status"`
+	SelfEndpoint                  *string
+}
+
+// StorageData - storage subsystem of a system with its controllers, volumes and drives
+type StorageData struct {
+	SystemID     string                  `json:"SystemId"`
+	ID           *string                 `json:"Id"`
+	Name         *string                 `json:"Name"`
+	Controllers  []StorageControllerData `json:"StorageControllers"`
+	Volumes      []VolumeData            `json:"Volumes"`
+	Drives       []DriveData             `json:"Drives"`
+	Status       redfish.Status          `json:"Status"`
+	SelfEndpoint *string
+}
+
+type storageResource struct {
+	ID                 *string                 `json:"Id"`
+	Name               *string                 `json:"Name"`
+	StorageControllers []StorageControllerData `json:"StorageControllers"`
+	Drives             []OData                 `json:"Drives"`
+	Volumes            *OData                  `json:"Volumes"`
+	Status             redfish.Status          `json:"Status"`
+}
+
+type volumeResource struct {
+	VolumeData
+	Links struct {
+		Drives []OData `json:"Drives"`
+	} `json:"Links"`
+}
+
+type driveResource struct {
+	DriveData
+	Links struct {
+		Volumes []OData `json:"Volumes"`
+	} `json:"Links"`
+}
+
+// normalizeEndpoint - path of an endpoint without scheme, host and trailing slash, links to the same resource
+// can be given in different forms
+func normalizeEndpoint(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err == nil {
+		endpoint = u.Path
+	}
+	return strings.TrimSuffix(endpoint, "/")
+}
+
+// fetchStorage - all storage subsystems of a system
+func fetchStorage(r redfish.Redfish, sys *redfish.SystemData) ([]*StorageData, error) {
+	var result = make([]*StorageData, 0)
+	var system systemResource
+	var sysID string
+
+	if sys.ID != nil {
+		sysID = *sys.ID
+	}
+
+	if sys.SelfEndpoint == nil {
+		return nil, fmt.Errorf("ERROR: No endpoint for system %s on %s", sysID, r.Hostname)
+	}
+
+	err := redfishGet(r, *sys.SelfEndpoint, &system)
+	if err != nil {
+		return nil, err
+	}
+
+	if system.Storage == nil || system.Storage.ID == nil {
+		return result, nil
+	}
+
+	members, err := collectionMembers(r, *system.Storage.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, member := range members {
+		var res storageResource
+
+		err = redfishGet(r, member, &res)
+		if err != nil {
+			return nil, err
+		}
+
+		ep := member
+		storage := StorageData{
+			SystemID:     sysID,
+			ID:           res.ID,
+			Name:         res.Name,
+			Controllers:  res.StorageControllers,
+			Volumes:      make([]VolumeData, 0),
+			Drives:       make([]DriveData, 0),
+			Status:       res.Status,
+			SelfEndpoint: &ep,
+		}
+		if storage.Controllers == nil {
+			storage.Controllers = make([]StorageControllerData, 0)
+		}
+
+		// volume endpoint -> ID of the volume
+		var volumeIDs = make(map[string]string)
+		// drive endpoint -> IDs of the volumes using the drive
+		var driveVolumes = make(map[string][]string)
+
+		if res.Volumes != nil && res.Volumes.ID != nil {
+			vmembers, err := collectionMembers(r, *res.Volumes.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, vmember := range vmembers {
+				var vol volumeResource
+
+				err = redfishGet(r, vmember, &vol)
+				if err != nil {
+					return nil, err
+				}
+
+				vep := vmember
+				vol.SelfEndpoint = &vep
+				storage.Volumes = append(storage.Volumes, vol.VolumeData)
+
+				vname := vmember
+				if vol.ID != nil {
+					vname = *vol.ID
+				}
+				volumeIDs[normalizeEndpoint(vmember)] = vname
+				for _, d := range odataList(vol.Links.Drives) {
+					key := normalizeEndpoint(d)
+					driveVolumes[key] = append(driveVolumes[key], vname)
+				}
+			}
+		}
+
+		for _, d := range odataList(res.Drives) {
+			var drive driveResource
+
+			err = redfishGet(r, d, &drive)
+			if err != nil {
+				return nil, err
+			}
+
+			dep := d
+			drive.SelfEndpoint = &dep
+			drive.Volumes = make([]string, 0)
+
+			// volumes can be linked by the volume, the drive or both
+			var seen = make(map[string]bool)
+			for _, vname := range driveVolumes[normalizeEndpoint(d)] {
+				seen[vname] = true
+				drive.Volumes = append(drive.Volumes, vname)
+			}
+			for _, v := range odataList(drive.Links.Volumes) {
+				vname, found := volumeIDs[normalizeEndpoint(v)]
+				if !found {
+					vname = path.Base(normalizeEndpoint(v))
+				}
+				if !seen[vname] {
+					seen[vname] = true
+					drive.Volumes = append(drive.Volumes, vname)
+				}
+			}
+
+			storage.Drives = append(storage.Drives, drive.DriveData)
+		}
+
+		result = append(result, &storage)
+	}
+
+	return result, nil
+}
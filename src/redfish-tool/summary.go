@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"os"
+)
+
+// FailureSummary - hosts on which the command failed
+type FailureSummary struct {
+	Hosts  int           `json:"hosts"`
+	Failed int           `json:"failed"`
+	Errors []HostFailure `json:"errors"`
+}
+
+// HostFailure - error of a single host
+type HostFailure struct {
+	Hostname string `json:"hostname"`
+	Error    string `json:"error"`
+}
+
+func buildFailureSummary(results []*hostResult) FailureSummary {
+	var summary = FailureSummary{
+		Hosts:  len(results),
+		Errors: make([]HostFailure, 0),
+	}
+
+	for _, result := range results {
+		if result.Err != nil {
+			summary.Failed++
+			summary.Errors = append(summary.Errors, HostFailure{
+				Hostname: result.Hostname,
+				Error:    result.Err.Error(),
+			})
+		}
+	}
+
+	return summary
+}
+
+func printFailureSummaryText(summary FailureSummary) string {
+	var result string
+
+	result = fmt.Sprintf("Command failed on %d of %d hosts\n", summary.Failed, summary.Hosts)
+	for _, f := range summary.Errors {
+		result += " " + f.Hostname + ": " + f.Error + "\n"
+	}
+
+	return result
+}
+
+func printFailureSummaryJSON(summary FailureSummary) string {
+	str, err := json.Marshal(summary)
+	// Should NEVER happen!
+	if err != nil {
+		log.Panic(err)
+	}
+
+	return string(str) + "\n"
+}
+
+// printFailureSummary - print list of failed hosts to stderr, stdout is reserved for the output of the command
+func printFailureSummary(results []*hostResult, format uint) {
+	summary := buildFailureSummary(results)
+	if summary.Failed == 0 {
+		return
+	}
+
+	if format == OutputJSON || format == OutputJSONArray {
+		fmt.Fprint(os.Stderr, printFailureSummaryJSON(summary))
+	} else {
+		fmt.Fprint(os.Stderr, printFailureSummaryText(summary))
+	}
+}
+
+// exitStatus - exit code for the results of all hosts
+func exitStatus(results []*hostResult) int {
+	var failed int
+
+	for _, result := range results {
+		if _, ok := result.Err.(usageError); ok {
+			return ExitUsage
+		}
+		if result.Err != nil {
+			failed++
+		}
+	}
+
+	if failed == 0 {
+		return ExitOK
+	}
+	if failed == len(results) {
+		return ExitFailure
+	}
+	return ExitPartialFailure
+}
@@ -5,9 +5,10 @@ import (
 	"flag"
 	"fmt"
 	redfish "git.ypbind.de/repository/go-redfish.git"
+	"io"
 )
 
-func systemPower(r redfish.Redfish, args []string) error {
+func systemPower(r redfish.Redfish, args []string, opts *OutputOptions, out io.Writer) error {
 	var sys *redfish.SystemData
 	var found bool
 	var smap map[string]*redfish.SystemData
@@ -21,15 +22,15 @@ func systemPower(r redfish.Redfish, args []string) error {
 	argParse.Parse(args)
 
 	if *uuid != "" && *id != "" {
-		return errors.New("ERROR: Options -uuid and -id are mutually exclusive")
+		return usageError("ERROR: Options -uuid and -id are mutually exclusive")
 	}
 
 	if *uuid == "" && *id == "" {
-		return errors.New("ERROR: Required options -uuid or -id not found")
+		return usageError("ERROR: Required options -uuid or -id not found")
 	}
 
 	if *state == "" {
-		return errors.New("ERROR: Option -state is mandatory")
+		return usageError("ERROR: Option -state is mandatory")
 	}
 
 	// Initialize session
@@ -0,0 +1,345 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	redfish "git.ypbind.de/repository/go-redfish.git"
+	log "github.com/sirupsen/logrus"
+)
+
+// TaskMessage - message of a task
+type TaskMessage struct {
+	MessageID *string `json:"MessageId"`
+	Message   *string `json:"Message"`
+	Severity  *string `json:"Severity"`
+}
+
+// TaskData - Redfish task of a long-running operation
+type TaskData struct {
+	ID              *string       `json:"Id"`
+	Name            *string       `json:"Name"`
+	TaskState       *string       `json:"TaskState"`
+	TaskStatus      *string       `json:"TaskStatus"`
+	PercentComplete *float64      `json:"PercentComplete"`
+	StartTime       *string       `json:"StartTime"`
+	EndTime         *string       `json:"EndTime"`
+	Messages        []TaskMessage `json:"Messages"`
+	SelfEndpoint    *string
+}
+
+type taskServiceResource struct {
+	Tasks *OData `json:"Tasks"`
+}
+
+// taskPollInterval - time between two requests for the state of a task
+const taskPollInterval = 10 * time.Second
+
+// finished - task has reached a final state
+func (t *TaskData) finished() bool {
+	if t.TaskState == nil {
+		return false
+	}
+
+	switch *t.TaskState {
+	case "Completed", "Killed", "Exception", "Cancelled":
+		return true
+	}
+	return false
+}
+
+// failed - error describing why the task failed, nil if the task completed successfully
+func (t *TaskData) failed(r redfish.Redfish) error {
+	var state string
+	var status string
+	var messages []string
+
+	if t.TaskState != nil {
+		state = *t.TaskState
+	}
+	if t.TaskStatus != nil {
+		status = *t.TaskStatus
+	}
+
+	if state == "Completed" && (status == "" || status == "OK" || status == "Warning") {
+		return nil
+	}
+
+	for _, m := range t.Messages {
+		if m.Message != nil {
+			messages = append(messages, *m.Message)
+		}
+	}
+
+	return fmt.Errorf("ERROR: Task %s on %s finished with state %s and status %s: %s", csvString(t.SelfEndpoint), r.Hostname, state, status, strings.Join(messages, "; "))
+}
+
+// taskLocation - URI of the task (monitor) returned by a request starting a long-running operation,
+// empty if the operation has been finished immediately
+func taskLocation(result HTTPResult) string {
+	var task TaskData
+	var link OData
+
+	location := result.Header.Get("Location")
+	if location != "" {
+		return location
+	}
+
+	// some vendors only return the task in the body
+	if json.Unmarshal(result.Content, &task) == nil && task.TaskState != nil && json.Unmarshal(result.Content, &link) == nil && link.ID != nil {
+		return *link.ID
+	}
+
+	return ""
+}
+
+// fetchTask - get a task or poll a task monitor, a task monitor replies with an empty body
+// or the result of the operation if the operation has been finished
+func fetchTask(r redfish.Redfish, uri string) (*TaskData, error) {
+	var task TaskData
+
+	result, err := redfishRequest(r, "GET", uri, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.StatusCode != http.StatusOK && result.StatusCode != http.StatusAccepted && result.StatusCode != http.StatusNoContent {
+		return nil, fmt.Errorf("ERROR: HTTP GET for %s returned \"%s\"", result.URL, result.Status)
+	}
+
+	if len(result.Content) > 0 {
+		err = json.Unmarshal(result.Content, &task)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// the task monitor is gone as soon as the operation has been finished
+	if task.TaskState == nil && result.StatusCode != http.StatusAccepted {
+		completed := "Completed"
+		task.TaskState = &completed
+	}
+
+	task.SelfEndpoint = &uri
+	return &task, nil
+}
+
+// waitForTask - poll a task until it is finished or timeout is reached, progress is logged
+func waitForTask(r redfish.Redfish, uri string, timeout time.Duration) (*TaskData, error) {
+	var lastState string
+	var lastPercent = -1.0
+
+	deadline := time.Now().Add(timeout)
+	for {
+		task, err := fetchTask(r, uri)
+		if err != nil {
+			return nil, err
+		}
+
+		state := csvString(task.TaskState)
+		percent := -1.0
+		if task.PercentComplete != nil {
+			percent = *task.PercentComplete
+		}
+
+		if state != lastState || percent != lastPercent {
+			log.WithFields(log.Fields{
+				"hostname":         r.Hostname,
+				"task":             uri,
+				"task_state":       state,
+				"percent_complete": csvFloat(task.PercentComplete),
+			}).Info("Task progress")
+			lastState = state
+			lastPercent = percent
+		}
+
+		if task.finished() {
+			return task, nil
+		}
+
+		if time.Now().After(deadline) {
+			return task, fmt.Errorf("ERROR: Timeout waiting for task %s on %s, last state is %s", uri, r.Hostname, state)
+		}
+
+		time.Sleep(taskPollInterval)
+	}
+}
+
+// fetchAllTasks - all tasks of the TaskService
+func fetchAllTasks(r redfish.Redfish) ([]*TaskData, error) {
+	var result = make([]*TaskData, 0)
+	var service taskServiceResource
+
+	endpoint, err := serviceEndpoint(r, "Tasks")
+	if err != nil {
+		return nil, err
+	}
+
+	err = redfishGet(r, endpoint, &service)
+	if err != nil {
+		return nil, err
+	}
+
+	if service.Tasks == nil || service.Tasks.ID == nil {
+		return nil, fmt.Errorf("ERROR: No task collection found in TaskService of %s", r.Hostname)
+	}
+
+	members, err := collectionMembers(r, *service.Tasks.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, member := range members {
+		var task TaskData
+
+		err = redfishGet(r, member, &task)
+		if err != nil {
+			return nil, err
+		}
+
+		ep := member
+		task.SelfEndpoint = &ep
+		result = append(result, &task)
+	}
+
+	return result, nil
+}
+
+// taskEndpointByID - endpoint of the task identified by id
+func taskEndpointByID(r redfish.Redfish, id string) (string, error) {
+	tlist, err := fetchAllTasks(r)
+	if err != nil {
+		return "", err
+	}
+
+	for _, task := range tlist {
+		if task.ID != nil && *task.ID == id {
+			return *task.SelfEndpoint, nil
+		}
+	}
+
+	return "", fmt.Errorf("ERROR: Task %s not found on %s", id, r.Hostname)
+}
+
+func printTaskText(r redfish.Redfish, tlist []*TaskData) string {
+	var result string
+
+	result = r.Hostname + "\n"
+	for _, task := range tlist {
+		if task.ID != nil {
+			result += " " + *task.ID + "\n"
+		} else {
+			result += " " + csvString(task.SelfEndpoint) + "\n"
+		}
+
+		if task.Name != nil {
+			result += "  Name: " + *task.Name + "\n"
+		}
+		if task.TaskState != nil {
+			result += "  TaskState: " + *task.TaskState + "\n"
+		}
+		if task.TaskStatus != nil {
+			result += "  TaskStatus: " + *task.TaskStatus + "\n"
+		}
+		if task.PercentComplete != nil {
+			result += "  PercentComplete: " + csvFloat(task.PercentComplete) + "\n"
+		}
+		if task.StartTime != nil {
+			result += "  StartTime: " + *task.StartTime + "\n"
+		}
+		if task.EndTime != nil {
+			result += "  EndTime: " + *task.EndTime + "\n"
+		}
+
+		if len(task.Messages) > 0 {
+			result += "  Messages:\n"
+			for _, m := range task.Messages {
+				result += "   " + csvString(m.Severity) + " " + csvString(m.MessageID) + ": " + csvString(m.Message) + "\n"
+			}
+		}
+
+		if task.SelfEndpoint != nil {
+			result += "  SelfEndpoint: " + *task.SelfEndpoint + "\n"
+		}
+	}
+
+	return result
+}
+
+func printTaskJSON(r redfish.Redfish, tlist []*TaskData) string {
+	var result string
+
+	for _, task := range tlist {
+		str, err := json.Marshal(task)
+		// Should NEVER happen!
+		if err != nil {
+			log.Panic(err)
+		}
+
+		result += fmt.Sprintf("{\"%s\":%s}\n", r.Hostname, string(str))
+	}
+
+	return result
+}
+
+// taskCSVHeader - columns of -format=table and -format=csv for tasks
+var taskCSVHeader = []string{"Hostname", "Id", "Name", "TaskState", "TaskStatus", "PercentComplete", "StartTime", "EndTime", "Messages", "SelfEndpoint"}
+
+func printTaskCSV(r redfish.Redfish, tlist []*TaskData) string {
+	var rows [][]string
+
+	for _, task := range tlist {
+		var messages []string
+		for _, m := range task.Messages {
+			if m.Message != nil {
+				messages = append(messages, *m.Message)
+			}
+		}
+
+		rows = append(rows, []string{
+			r.Hostname,
+			csvString(task.ID),
+			csvString(task.Name),
+			csvString(task.TaskState),
+			csvString(task.TaskStatus),
+			csvFloat(task.PercentComplete),
+			csvString(task.StartTime),
+			csvString(task.EndTime),
+			strings.Join(messages, "; "),
+			csvString(task.SelfEndpoint),
+		})
+	}
+
+	return formatCSV(taskCSVHeader, rows)
+}
+
+func printTasks(r redfish.Redfish, tlist []*TaskData, opts *OutputOptions) (string, error) {
+	if opts.Format == OutputJSON {
+		return printTaskJSON(r, tlist), nil
+	}
+
+	if opts.Format == OutputJSONArray {
+		return formatJSONData(tlist), nil
+	}
+
+	if opts.Format == OutputYAML {
+		return formatYAML(r, tlist), nil
+	}
+
+	if opts.Format == OutputTemplate {
+		var list = make([]interface{}, 0, len(tlist))
+		for _, task := range tlist {
+			list = append(list, task)
+		}
+		return formatTemplate(r, opts, list...)
+	}
+
+	if opts.Format == OutputTable || opts.Format == OutputCSV {
+		return printTaskCSV(r, tlist), nil
+	}
+
+	return printTaskText(r, tlist), nil
+}
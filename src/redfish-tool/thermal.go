@@ -0,0 +1,258 @@
+package main
+
+import (
+	redfish "git.ypbind.de/repository/go-redfish.git"
+)
+
+// SensorReading - reading and thresholds of a temperature or fan sensor
+type SensorReading struct {
+	Name                   *string        `json:"Name"`
+	Reading                *float64       `json:"Reading"`
+	ReadingUnits           *string        `json:"ReadingUnits"`
+	LowerThresholdCritical *float64       `json:"LowerThresholdCritical"`
+	UpperThresholdCritical *float64       `json:"UpperThresholdCritical"`
+	LowerThresholdFatal    *float64       `json:"LowerThresholdFatal"`
+	UpperThresholdFatal    *float64       `json:"UpperThresholdFatal"`
+	Status                 redfish.Status `json:"Status"`
+}
+
+// ThermalData - temperature and fan sensors of a chassis
+type ThermalData struct {
+	ChassisID    string          `json:"ChassisId"`
+	Temperatures []SensorReading `json:"Temperatures"`
+	Fans         []SensorReading `json:"Fans"`
+	SelfEndpoint *string
+}
+
+// thermalSensor - sensor of the (deprecated) Thermal resource
+type thermalSensor struct {
+	MemberID               *string        `json:"MemberId"`
+	Name                   *string        `json:"Name"`
+	FanName                *string        `json:"FanName"`
+	ReadingCelsius         *float64       `json:"ReadingCelsius"`
+	Reading                *float64       `json:"Reading"`
+	ReadingUnits           *string        `json:"ReadingUnits"`
+	LowerThresholdCritical *float64       `json:"LowerThresholdCritical"`
+	UpperThresholdCritical *float64       `json:"UpperThresholdCritical"`
+	LowerThresholdFatal    *float64       `json:"LowerThresholdFatal"`
+	UpperThresholdFatal    *float64       `json:"UpperThresholdFatal"`
+	Status                 redfish.Status `json:"Status"`
+}
+
+type thermalResource struct {
+	Temperatures []thermalSensor `json:"Temperatures"`
+	Fans         []thermalSensor `json:"Fans"`
+}
+
+type thermalSubsystemResource struct {
+	Fans           *OData `json:"Fans"`
+	ThermalMetrics *OData `json:"ThermalMetrics"`
+}
+
+type thermalMetricsResource struct {
+	TemperatureReadingsCelsius []struct {
+		DeviceName    *string  `json:"DeviceName"`
+		DataSourceURI *string  `json:"DataSourceUri"`
+		Reading       *float64 `json:"Reading"`
+	} `json:"TemperatureReadingsCelsius"`
+}
+
+type fanResource struct {
+	ID           *string `json:"Id"`
+	Name         *string `json:"Name"`
+	SpeedPercent *struct {
+		DataSourceURI *string  `json:"DataSourceUri"`
+		Reading       *float64 `json:"Reading"`
+	} `json:"SpeedPercent"`
+	Status redfish.Status `json:"Status"`
+}
+
+// readingValue - reading of a sensor or threshold
+type readingValue struct {
+	Reading *float64 `json:"Reading"`
+}
+
+// sensorResource - sensor from the Sensors collection of a chassis, used by ThermalSubsystem and PowerSubsystem
+type sensorResource struct {
+	ID           *string  `json:"Id"`
+	Name         *string  `json:"Name"`
+	Reading      *float64 `json:"Reading"`
+	ReadingUnits *string  `json:"ReadingUnits"`
+	Thresholds   struct {
+		LowerCritical *readingValue `json:"LowerCritical"`
+		UpperCritical *readingValue `json:"UpperCritical"`
+		LowerFatal    *readingValue `json:"LowerFatal"`
+		UpperFatal    *readingValue `json:"UpperFatal"`
+	} `json:"Thresholds"`
+	Status redfish.Status `json:"Status"`
+}
+
+// readingOf - reading of an optional value
+func readingOf(t *readingValue) *float64 {
+	if t == nil {
+		return nil
+	}
+	return t.Reading
+}
+
+// fetchSensor - reading and thresholds from a sensor resource
+func fetchSensor(r redfish.Redfish, endpoint string) (SensorReading, error) {
+	var sensor sensorResource
+
+	err := redfishGet(r, endpoint, &sensor)
+	if err != nil {
+		return SensorReading{}, err
+	}
+
+	return SensorReading{
+		Name:                   sensor.Name,
+		Reading:                sensor.Reading,
+		ReadingUnits:           sensor.ReadingUnits,
+		LowerThresholdCritical: readingOf(sensor.Thresholds.LowerCritical),
+		UpperThresholdCritical: readingOf(sensor.Thresholds.UpperCritical),
+		LowerThresholdFatal:    readingOf(sensor.Thresholds.LowerFatal),
+		UpperThresholdFatal:    readingOf(sensor.Thresholds.UpperFatal),
+		Status:                 sensor.Status,
+	}, nil
+}
+
+func thermalSensorReading(s thermalSensor, units string) SensorReading {
+	result := SensorReading{
+		Name:                   s.Name,
+		Reading:                s.Reading,
+		ReadingUnits:           s.ReadingUnits,
+		LowerThresholdCritical: s.LowerThresholdCritical,
+		UpperThresholdCritical: s.UpperThresholdCritical,
+		LowerThresholdFatal:    s.LowerThresholdFatal,
+		UpperThresholdFatal:    s.UpperThresholdFatal,
+		Status:                 s.Status,
+	}
+
+	if result.Name == nil {
+		result.Name = s.FanName
+	}
+	if result.Name == nil {
+		result.Name = s.MemberID
+	}
+
+	if s.ReadingCelsius != nil {
+		result.Reading = s.ReadingCelsius
+	}
+	if result.ReadingUnits == nil && units != "" {
+		result.ReadingUnits = &units
+	}
+
+	return result
+}
+
+// fetchThermal - temperature and fan sensors of a chassis from the Thermal resource
+// or from ThermalSubsystem if Thermal is not provided
+func fetchThermal(r redfish.Redfish, chassis *ChassisData) (*ThermalData, error) {
+	var result = ThermalData{
+		ChassisID:    *chassis.ID,
+		Temperatures: make([]SensorReading, 0),
+		Fans:         make([]SensorReading, 0),
+	}
+
+	if chassis.Thermal != nil && chassis.Thermal.ID != nil {
+		var thermal thermalResource
+
+		err := redfishGet(r, *chassis.Thermal.ID, &thermal)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, t := range thermal.Temperatures {
+			result.Temperatures = append(result.Temperatures, thermalSensorReading(t, "Cel"))
+		}
+		for _, f := range thermal.Fans {
+			result.Fans = append(result.Fans, thermalSensorReading(f, ""))
+		}
+
+		result.SelfEndpoint = chassis.Thermal.ID
+		return &result, nil
+	}
+
+	if chassis.ThermalSubsystem == nil || chassis.ThermalSubsystem.ID == nil {
+		return nil, nil
+	}
+
+	var subsys thermalSubsystemResource
+	err := redfishGet(r, *chassis.ThermalSubsystem.ID, &subsys)
+	if err != nil {
+		return nil, err
+	}
+
+	if subsys.ThermalMetrics != nil && subsys.ThermalMetrics.ID != nil {
+		var metrics thermalMetricsResource
+
+		err = redfishGet(r, *subsys.ThermalMetrics.ID, &metrics)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, t := range metrics.TemperatureReadingsCelsius {
+			// thresholds are only available from the sensor itself
+			if t.DataSourceURI != nil {
+				sensor, err := fetchSensor(r, *t.DataSourceURI)
+				if err != nil {
+					return nil, err
+				}
+				result.Temperatures = append(result.Temperatures, sensor)
+				continue
+			}
+
+			units := "Cel"
+			result.Temperatures = append(result.Temperatures, SensorReading{
+				Name:         t.DeviceName,
+				Reading:      t.Reading,
+				ReadingUnits: &units,
+			})
+		}
+	}
+
+	if subsys.Fans != nil && subsys.Fans.ID != nil {
+		members, err := collectionMembers(r, *subsys.Fans.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, member := range members {
+			var fan fanResource
+
+			err = redfishGet(r, member, &fan)
+			if err != nil {
+				return nil, err
+			}
+
+			if fan.SpeedPercent != nil && fan.SpeedPercent.DataSourceURI != nil {
+				sensor, err := fetchSensor(r, *fan.SpeedPercent.DataSourceURI)
+				if err != nil {
+					return nil, err
+				}
+				if fan.Name != nil {
+					sensor.Name = fan.Name
+				}
+				if fan.Status.Health != nil {
+					sensor.Status = fan.Status
+				}
+				result.Fans = append(result.Fans, sensor)
+				continue
+			}
+
+			units := "%"
+			sensor := SensorReading{
+				Name:         fan.Name,
+				ReadingUnits: &units,
+				Status:       fan.Status,
+			}
+			if fan.SpeedPercent != nil {
+				sensor.Reading = fan.SpeedPercent.Reading
+			}
+			result.Fans = append(result.Fans, sensor)
+		}
+	}
+
+	result.SelfEndpoint = chassis.ThermalSubsystem.ID
+	return &result, nil
+}
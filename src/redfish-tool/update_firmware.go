@@ -0,0 +1,218 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	redfish "git.ypbind.de/repository/go-redfish.git"
+	log "github.com/sirupsen/logrus"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// simpleUpdate - let the management board fetch the image from uri using UpdateService.SimpleUpdate
+func simpleUpdate(r redfish.Redfish, update *updateServiceResource, uri string, protocol string, targets []string) (HTTPResult, error) {
+	var payload = make(map[string]interface{})
+
+	if update.Actions.SimpleUpdate == nil || update.Actions.SimpleUpdate.Target == nil {
+		return HTTPResult{}, fmt.Errorf("ERROR: UpdateService.SimpleUpdate is not provided by %s", r.Hostname)
+	}
+
+	payload["ImageURI"] = uri
+	if protocol != "" {
+		payload["TransferProtocol"] = protocol
+	}
+	if len(targets) > 0 {
+		payload["Targets"] = targets
+	}
+
+	return redfishSend(r, "POST", *update.Actions.SimpleUpdate.Target, payload)
+}
+
+// pushUpdate - upload the image file to MultipartHttpPushUri or, if not provided or httpPush is set, to HttpPushUri
+// The image is streamed from the file, timeout limits the whole upload including the reply of the management board
+func pushUpdate(r redfish.Redfish, update *updateServiceResource, file string, targets []string, httpPush bool, timeout time.Duration) (HTTPResult, error) {
+	var result HTTPResult
+
+	image, err := os.Open(file)
+	if err != nil {
+		return result, err
+	}
+	defer image.Close()
+
+	info, err := image.Stat()
+	if err != nil {
+		return result, err
+	}
+
+	if !httpPush && update.MultipartHTTPPushURI != nil {
+		var body bytes.Buffer
+		var params = make(map[string]interface{})
+
+		if len(targets) > 0 {
+			params["Targets"] = targets
+		}
+		params["@Redfish.OperationApplyTime"] = "Immediate"
+
+		w := multipart.NewWriter(&body)
+
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", `form-data; name="UpdateParameters"`)
+		header.Set("Content-Type", "application/json")
+		part, err := w.CreatePart(header)
+		if err != nil {
+			return result, err
+		}
+		_, err = part.Write([]byte(formatJSONData(params)))
+		if err != nil {
+			return result, err
+		}
+
+		header = make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="UpdateFile"; filename="%s"`, filepath.Base(file)))
+		header.Set("Content-Type", "application/octet-stream")
+		_, err = w.CreatePart(header)
+		if err != nil {
+			return result, err
+		}
+
+		// the image is sent between the part header and the closing boundary, so the length of the
+		// request is known in advance
+		head := append([]byte(nil), body.Bytes()...)
+		body.Reset()
+		err = w.Close()
+		if err != nil {
+			return result, err
+		}
+		tail := body.Bytes()
+
+		length := int64(len(head)) + info.Size() + int64(len(tail))
+		result, err = redfishUpload(r, "POST", *update.MultipartHTTPPushURI, w.FormDataContentType(), io.MultiReader(bytes.NewReader(head), image, bytes.NewReader(tail)), length, timeout)
+	} else if update.HTTPPushURI != nil {
+		if len(targets) > 0 {
+			return result, errors.New("ERROR: Option -targets requires MultipartHttpPushUri")
+		}
+		result, err = redfishUpload(r, "POST", *update.HTTPPushURI, "application/octet-stream", image, info.Size(), timeout)
+	} else {
+		return result, fmt.Errorf("ERROR: Neither MultipartHttpPushUri nor HttpPushUri are provided by %s", r.Hostname)
+	}
+
+	if err != nil {
+		return result, err
+	}
+
+	if result.StatusCode < 200 || result.StatusCode > 299 {
+		return result, fmt.Errorf("ERROR: HTTP POST for %s returned \"%s\"", result.URL, result.Status)
+	}
+
+	return result, nil
+}
+
+func updateFirmware(r redfish.Redfish, args []string, opts *OutputOptions, out io.Writer) error {
+	var result HTTPResult
+	var targets []string
+
+	argParse := flag.NewFlagSet("update-firmware", flag.ExitOnError)
+
+	var uri = argParse.String("uri", "", "URI of the firmware image, fetched by the management board")
+	var protocol = argParse.String("protocol", "", "Transfer protocol used to fetch the image from -uri")
+	var file = argParse.String("file", "", "Upload local firmware image")
+	var httpPush = argParse.Bool("http-push", false, "Upload image to HttpPushUri instead of MultipartHttpPushUri")
+	var _targets = argParse.String("targets", "", "Comma separated list of components to update")
+	var noWait = argParse.Bool("no-wait", false, "Don't wait for the update to finish")
+	var waitTimeout = argParse.Uint("wait-timeout", 3600, "Time in seconds to wait for the update to finish")
+	var uploadTimeout = argParse.Uint("upload-timeout", 3600, "Time in seconds to upload the image")
+
+	argParse.Parse(args)
+
+	if *uri != "" && *file != "" {
+		return usageError("ERROR: Options -uri and -file are mutually exclusive")
+	}
+
+	if *uri == "" && *file == "" {
+		return usageError("ERROR: Required options -uri or -file not found")
+	}
+
+	if *protocol != "" && *uri == "" {
+		return usageError("ERROR: Option -protocol requires -uri")
+	}
+
+	if *httpPush && *file == "" {
+		return usageError("ERROR: Option -http-push requires -file")
+	}
+
+	for _, t := range strings.Split(*_targets, ",") {
+		t = strings.TrimSpace(t)
+		if t != "" {
+			targets = append(targets, t)
+		}
+	}
+
+	// Initialize session
+	err := r.Initialise()
+	if err != nil {
+		return fmt.Errorf("ERROR: Initialisation failed for %s: %s", r.Hostname, err.Error())
+	}
+
+	// Login
+	err = r.Login()
+	if err != nil {
+		return fmt.Errorf("ERROR: Login to %s failed: %s", r.Hostname, err.Error())
+	}
+
+	defer r.Logout()
+
+	update, err := fetchUpdateService(r)
+	if err != nil {
+		return err
+	}
+
+	if *uri != "" {
+		result, err = simpleUpdate(r, update, *uri, strings.ToUpper(*protocol), targets)
+	} else {
+		result, err = pushUpdate(r, update, *file, targets, *httpPush, time.Duration(*uploadTimeout)*time.Second)
+	}
+	if err != nil {
+		return err
+	}
+
+	location := taskLocation(result)
+	if location == "" {
+		if r.Verbose {
+			log.WithFields(log.Fields{
+				"hostname": r.Hostname,
+			}).Info("Firmware update finished without task")
+		}
+		return nil
+	}
+
+	if *noWait {
+		task := TaskData{SelfEndpoint: &location}
+		output, err := printTasks(r, []*TaskData{&task}, opts)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, output)
+		return nil
+	}
+
+	task, err := waitForTask(r, location, time.Duration(*waitTimeout)*time.Second)
+	if task != nil {
+		output, perr := printTasks(r, []*TaskData{task}, opts)
+		if perr != nil {
+			return perr
+		}
+		fmt.Fprintln(out, output)
+	}
+	if err != nil {
+		return err
+	}
+
+	return task.failed(r)
+}
@@ -6,28 +6,62 @@ import (
 
 func showUsage() {
 	showVersion()
-	fmt.Printf("Usage redfish-tool [-ask] [-help] [-password=<pass>] [-password-file=<file>]\n" +
-		"       -user=<user> -host=<host>[,<host>,...] [-verbose] [-timeout <sec>] [-port <port>]\n" +
-		"       [-insecure] [-version] [-format=<format>] <command> [<cmd_options>]\n" +
+	fmt.Printf("Usage redfish-tool [-ask] [-help] [-password=<pass>] [-password-file=<file>] [-config=<file>]\n" +
+		"       -user=<user> -host=<host>[,<host>,...] [-hosts-file=<file>] [-verbose] [-timeout <sec>] [-port <port>]\n" +
+		"       [-insecure] [-version] [-format=<format>] [-template=<template>] [-template-file=<file>]\n" +
+		"       [-sort=<field>] [-reverse] [-fields=<field>,...] [-filter=<expr>] [-parallel=<n>]\n" +
+		"       <command> [<cmd_options>]\n" +
 		"\n" +
 		"Global options:\n" +
 		"\n" +
 		"  -ask\n" +
 		"    	Ask for password\n" +
+		"  -config=<file>\n" +
+		"       Read connection settings from configuration file <file>\n" +
+		"       Options on the command line take precedence over the configuration file\n" +
 		"  -debug\n" +
 		"    	Debug operation\n" +
+		"  -fields=<field>,<field>,...\n" +
+		"       Only print <field>,... of the items listed by get-all-* and get-firmware, e.g. UserName,RoleID,Enabled\n" +
+		"       Unknown fields are rejected\n" +
+		"  -filter=<field>=<value>, -filter=<field>!=<value>\n" +
+		"       Only list items of get-all-* and get-firmware if <field> is (=) or is not (!=) <value>,\n" +
+		"       e.g. Enabled=false or PowerState!=On. Values are compared case-insensitive\n" +
+		"       Can be used multiple times, items must match all filters. Unknown fields are rejected\n" +
 		"  -format=<format>\n" +
 		"       Output format. Valid formats are:\n" +
 		"         text - Text output\n" +
-		"         json - JSON output\n" +
+		"         json - JSON output, one object per line\n" +
+		"         json-array - Results of all hosts as a single JSON array\n" +
+		"         yaml - YAML output, a mapping of host names to results\n" +
+		"         table - Aligned table, one row per item (*)\n" +
+		"         csv - CSV with header line, one row per item (*)\n" +
+		"         template - Render every item by the template set by -template or -template-file\n" +
+		"       (*) only supported by get-* commands, the output of all hosts is merged\n" +
 		"       Default: text\n" +
 		"\n" +
+		"  -template=<template>\n" +
+		"       Template for output format template, see https://golang.org/pkg/text/template/\n" +
+		"       The fields of every item and the host name as .Host are available in the template\n" +
+		"       Implies -format=template\n" +
+		"  -template-file=<file>\n" +
+		"       Read template for output format template from <file>. Implies -format=template\n" +
+		"\n" +
 		"  -help\n" +
 		"    	Show help text\n" +
 		"  -host=<host>[,<host>,...]\n" +
-		"       Systems to connect to\n" +
+		"       Systems to connect to, <host> can be <name>, <name>:<port> or @<group>\n" +
+		"       for a group of hosts from the hosts file\n" +
+		"       If omitted all hosts from the hosts file will be used\n" +
+		"  -hosts-file=<file>\n" +
+		"       Read hosts and host groups from <file>, use - to read from standard input\n" +
+		"       One host per line: <name>[:<port>] [user=<user>] [port=<port>]\n" +
+		"       A line [<group>] starts the host group <group>, # starts a comment\n" +
 		"  -insecure\n" +
 		"    	Skip SSL certificate verification\n" +
+		"  -parallel=<n>\n" +
+		"       Run command on <n> hosts in parallel. Default: 1\n" +
+		"       Output is grouped by host and printed in the order of the host list\n" +
 		"  -password=<pass>\n" +
 		"    	Password to use for authentication\n" +
 		"  -password-file=<file>\n" +
@@ -36,6 +70,11 @@ func showUsage() {
 		"       Connect to <port>. Default: 443\n" +
 		"  -user=<user>\n" +
 		"    	Username to use for authentication\n" +
+		"  -reverse\n" +
+		"       Sort listings in reverse order\n" +
+		"  -sort=<field>\n" +
+		"       Sort listings of get-all-* and get-firmware by <field>, e.g. UserName or Status.Health\n" +
+		"       Default: sort by name (accounts) or ID. Unknown fields are rejected\n" +
 		"  -timeout <sec>\n" +
 		"       Connection timeout in seconds. Default: 60\n" +
 		"  -verbose\n" +
@@ -200,6 +239,53 @@ func showUsage() {
 		"\n" +
 		"    (*) -uuid and -id are mutually exclusive\n" +
 		"\n" +
+		"  get-inventory - List processors, memory, PCIe devices and network adapters of systems\n" +
+		"    -uuid=<uuid>\n" +
+		"         Only list inventory of system identified by UUID (*)\n" +
+		"    -id=<id>\n" +
+		"         Only list inventory of system identified by ID (*)\n" +
+		"\n" +
+		"    (*) -uuid and -id are mutually exclusive, default: all systems\n" +
+		"\n" +
+		"  get-storage - List storage controllers, volumes and drives of systems\n" +
+		"    -uuid=<uuid>\n" +
+		"         Only list storage of system identified by UUID (*)\n" +
+		"    -id=<id>\n" +
+		"         Only list storage of system identified by ID (*)\n" +
+		"\n" +
+		"    (*) -uuid and -id are mutually exclusive, default: all systems\n" +
+		"\n" +
+		"  get-bios - Show BIOS attributes and pending changes of systems\n" +
+		"    -uuid=<uuid>\n" +
+		"         Only show BIOS attributes of system identified by UUID (*)\n" +
+		"    -id=<id>\n" +
+		"         Only show BIOS attributes of system identified by ID (*)\n" +
+		"    -attributes=<name>,...\n" +
+		"         Only show the attributes <name>,...\n" +
+		"    -pending\n" +
+		"         Only show attributes with changes pending until the next reboot\n" +
+		"\n" +
+		"    (*) -uuid and -id are mutually exclusive, default: all systems\n" +
+		"\n" +
+		"  set-bios - Set BIOS attributes of a system, changes are applied on the next reboot\n" +
+		"    -uuid=<uuid>\n" +
+		"         Set BIOS attributes of system identified by UUID (*)\n" +
+		"    -id=<id>\n" +
+		"         Set BIOS attributes of system identified by ID (*)\n" +
+		"    -file=<file>\n" +
+		"         Read attributes from JSON file <file>\n" +
+		"    -apply-time=<time>\n" +
+		"         Request when the settings are applied, e.g. OnReset or Immediate\n" +
+		"    -reset-defaults\n" +
+		"         Reset BIOS attributes to their default values\n" +
+		"    -no-validate\n" +
+		"         Don't validate attributes against the BIOS attribute registry\n" +
+		"    <name>=<value> ...\n" +
+		"         Set attribute <name> to <value>, the type is taken from the BIOS attribute registry.\n" +
+		"         Without validation <value> is sent as string\n" +
+		"\n" +
+		"    (*) -uuid and -id are mutually exclusive, one of them is required\n" +
+		"\n" +
 
 		"\n" +
 		"  system-power - Set power state of a system\n" +
@@ -219,6 +305,199 @@ func showUsage() {
 		"\n" +
 		"    (*) -uuid and -id are mutually exclusive\n" +
 		"\n" +
+		"  set-boot - Set boot source override of a system\n" +
+		"    -uuid=<uuid>\n" +
+		"       Set boot source override for system identified by UUID (*)\n" +
+		"    -id=<id>\n" +
+		"       Set boot source override for system identified by ID (*)\n" +
+		"    -target=<target>\n" +
+		"       Boot source, e.g. Pxe, Cd, Hdd, BiosSetup, UefiHttp or UefiTarget\n" +
+		"    -enabled=<enabled>\n" +
+		"       Once, Continuous or Disabled. Default: Once\n" +
+		"    -mode=<mode>\n" +
+		"       Boot mode, UEFI or Legacy\n" +
+		"    -uefi-target=<path>\n" +
+		"       UEFI device path to boot from, mandatory for -target=UefiTarget\n" +
+		"    -reset=<state>\n" +
+		"       Set power state of the system after setting the boot source override, e.g. ForceRestart\n" +
+		"\n" +
+		"    (*) -uuid and -id are mutually exclusive\n" +
+		"\n" +
+		"  get-boot-order - Show persistent boot order of systems with the boot options\n" +
+		"    -uuid=<uuid>\n" +
+		"       Only show boot order of system identified by UUID (*)\n" +
+		"    -id=<id>\n" +
+		"       Only show boot order of system identified by ID (*)\n" +
+		"\n" +
+		"    (*) -uuid and -id are mutually exclusive, default: all systems\n" +
+		"\n" +
+		"  set-boot-order - Set persistent boot order of a system\n" +
+		"    -uuid=<uuid>\n" +
+		"       Set boot order of system identified by UUID (*)\n" +
+		"    -id=<id>\n" +
+		"       Set boot order of system identified by ID (*)\n" +
+		"    -order=<entry>,...\n" +
+		"       New boot order, entries are boot option references, aliases or display name patterns (* and ?)\n" +
+		"    -force\n" +
+		"       Remove entries of the current boot order which are not part of the new boot order\n" +
+		"\n" +
+		"    (*) -uuid and -id are mutually exclusive\n" +
+		"\n" +
+		" # Chassis operations:\n" +
+		"\n" +
+		"  get-all-chassis - List all chassis\n" +
+		"\n" +
+		"  get-chassis - List specific chassis\n" +
+		"    -uuid=<uuid>\n" +
+		"         Get detailed information for chassis identified by UUID (*)\n" +
+		"    -id=<id>\n" +
+		"         Get detailed information for chassis identified by ID (*)\n" +
+		"\n" +
+		"    (*) -uuid and -id are mutually exclusive\n" +
+		"\n" +
+		"  get-thermal - Show temperature and fan sensors\n" +
+		"    -id=<id>\n" +
+		"         Only show sensors of chassis identified by ID. Default: all chassis\n" +
+		"\n" +
+		"  get-power - Show power supplies and power consumption\n" +
+		"    -id=<id>\n" +
+		"         Only show power data of chassis identified by ID. Default: all chassis\n" +
+		"\n" +
+		"  set-power-limit - Set or remove power limit of a chassis\n" +
+		"    Not supported by HP/HPE and Inspur servers\n" +
+		"    -id=<id>\n" +
+		"         Set power limit of chassis identified by ID (**)\n" +
+		"    -all\n" +
+		"         Set power limit of all chassis with power control (**)\n" +
+		"    -member=<id>\n" +
+		"         Set power limit of power control identified by member ID. Default: first power control\n" +
+		"    -limit=<watts>\n" +
+		"         Power limit in watts (*)\n" +
+		"    -exception=<action>\n" +
+		"         Action if the power limit is exceeded: NoAction, HardPowerOff, LogEventOnly or Oem\n" +
+		"    -clear\n" +
+		"         Remove power limit (*)\n" +
+		"\n" +
+		"    (*) -limit and -clear are mutually exclusive\n" +
+		"    (**) -id and -all are mutually exclusive, one of them is required\n" +
+		"\n" +
+		" # Firmware operations:\n" +
+		"\n" +
+		"  get-firmware - List firmware components (BIOS, management board, NIC, RAID controller, ...)\n" +
+		"\n" +
+		"  update-firmware - Update firmware and wait for the update task to finish\n" +
+		"    -uri=<uri>\n" +
+		"         Let the management board fetch the firmware image from <uri> (*)\n" +
+		"    -protocol=<protocol>\n" +
+		"         Transfer protocol used to fetch the image from <uri>, e.g. HTTP, HTTPS, FTP, SFTP, TFTP, NFS or CIFS\n" +
+		"    -file=<file>\n" +
+		"         Upload the firmware image <file> (*)\n" +
+		"    -http-push\n" +
+		"         Upload the image to HttpPushUri instead of MultipartHttpPushUri\n" +
+		"    -upload-timeout=<sec>\n" +
+		"         Upload the image from <file> in at most <sec> seconds, 0 for no limit. Default: 3600\n" +
+		"    -targets=<target>,...\n" +
+		"         Only update the components <target>,... (URIs from the firmware inventory)\n" +
+		"    -no-wait\n" +
+		"         Don't wait for the update task to finish\n" +
+		"    -wait-timeout=<sec>\n" +
+		"         Wait at most <sec> seconds for the update task to finish. Default: 3600\n" +
+		"\n" +
+		" # Task operations:\n" +
+		"\n" +
+		"  get-tasks - List tasks of the task service\n" +
+		"    -id=<id>\n" +
+		"         Only show task identified by ID (*)\n" +
+		"    -uri=<uri>\n" +
+		"         Only show task or task monitor identified by URI (*)\n" +
+		"\n" +
+		"    (*) -id and -uri are mutually exclusive\n" +
+		"\n" +
+		"  wait-task - Wait for a task to finish, fails if the task fails\n" +
+		"    -id=<id>\n" +
+		"         Wait for task identified by ID (*)\n" +
+		"    -uri=<uri>\n" +
+		"         Wait for task or task monitor identified by URI (*)\n" +
+		"    -wait-timeout=<sec>\n" +
+		"         Wait at most <sec> seconds for the task to finish. Default: 3600\n" +
+		"\n" +
+		"    (*) -id and -uri are mutually exclusive\n" +
+		"\n" +
+		" # Log operations:\n" +
+		"\n" +
+		"  get-logs - Show entries of the log services (e.g. SEL, IML or Lifecycle log) of managers and systems\n" +
+		"    -manager=<id>\n" +
+		"         Only read log services of manager identified by ID (*)\n" +
+		"    -system=<id>\n" +
+		"         Only read log services of system identified by ID (*)\n" +
+		"    -log=<id>\n" +
+		"         Only read log service identified by ID\n" +
+		"    -severity=<severity>\n" +
+		"         Only show entries with at least severity <severity>: OK, Warning or Critical\n" +
+		"    -since=<time>\n" +
+		"         Only show entries created at or after <time> (RFC3339 or YYYY-MM-DD)\n" +
+		"\n" +
+		"    (*) -manager and -system are mutually exclusive\n" +
+		"\n" +
+		"  clear-log - Clear a log service\n" +
+		"    -manager=<id>\n" +
+		"         Clear log service of manager identified by ID (*)\n" +
+		"    -system=<id>\n" +
+		"         Clear log service of system identified by ID (*)\n" +
+		"    -log=<id>\n" +
+		"         Clear log service identified by ID\n" +
+		"\n" +
+		"    (*) -manager and -system are mutually exclusive\n" +
+		"\n" +
+		"    (*) -uri and -file are mutually exclusive\n" +
+		"\n" +
+		" # Virtual media operations:\n" +
+		"\n" +
+		"  get-media - List virtual media slots of managers and systems\n" +
+		"    -manager=<id>\n" +
+		"         Only list virtual media of manager identified by ID (*)\n" +
+		"    -system=<id>\n" +
+		"         Only list virtual media of system identified by ID (*)\n" +
+		"    -media=<id>\n" +
+		"         Only list virtual media identified by ID\n" +
+		"\n" +
+		"    (*) -manager and -system are mutually exclusive\n" +
+		"\n" +
+		"  insert-media - Insert an image into a virtual media slot\n" +
+		"    -manager=<id>\n" +
+		"         Use virtual media of manager identified by ID (*)\n" +
+		"    -system=<id>\n" +
+		"         Use virtual media of system identified by ID (*)\n" +
+		"    -media=<id>\n" +
+		"         Insert image into virtual media identified by ID. Default: first CD/DVD drive\n" +
+		"    -image=<url>\n" +
+		"         URL of the image to insert\n" +
+		"    -write-protected=<true|false>\n" +
+		"         Insert image write protected. Default: true\n" +
+		"    -user=<user>\n" +
+		"         User name to access the image\n" +
+		"    -password=<pass>\n" +
+		"         Password to access the image\n" +
+		"    -password-file=<file>\n" +
+		"         Read password to access the image from <file>. The password MUST be the first line in the file, all other lines are ignored\n" +
+		"    -protocol=<protocol>\n" +
+		"         Transfer protocol used to access the image, e.g. HTTP, HTTPS, NFS or CIFS\n" +
+		"\n" +
+		"    (*) -manager and -system are mutually exclusive\n" +
+		"\n" +
+		"  eject-media - Eject images from virtual media slots\n" +
+		"    -manager=<id>\n" +
+		"         Use virtual media of manager identified by ID (*)\n" +
+		"    -system=<id>\n" +
+		"         Use virtual media of system identified by ID (*)\n" +
+		"    -media=<id>\n" +
+		"         Eject image from virtual media identified by ID (**)\n" +
+		"    -all\n" +
+		"         Eject all inserted images of the selected managers and systems (**)\n" +
+		"\n" +
+		"    (*) -manager and -system are mutually exclusive\n" +
+		"    (**) -media and -all are mutually exclusive, one of them is required\n" +
+		"\n" +
 		"# License operations:\n" +
 		"## Only supported by:\n" +
 		"    * HP/HPE\n" +
@@ -240,5 +519,12 @@ func showUsage() {
 		"       License file containing the additional license\n" +
 		"\n" +
 		"    (*) -uuid and -id are mutually exclusive\n" +
+		"\n" +
+		"Exit codes:\n" +
+		"\n" +
+		"  0 - Command succeeded on all hosts\n" +
+		"  1 - Command failed on all hosts or could not be started at all\n" +
+		"  2 - Invalid or missing options or commands\n" +
+		"  3 - Command failed on some, but not all, hosts\n" +
 		"\n")
 }
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	redfish "git.ypbind.de/repository/go-redfish.git"
+)
+
+// VirtualMediaData - virtual media slot of a manager or system
+type VirtualMediaData struct {
+	SourceType           string   `json:"SourceType"`
+	SourceID             string   `json:"SourceId"`
+	ID                   *string  `json:"Id"`
+	Name                 *string  `json:"Name"`
+	MediaTypes           []string `json:"MediaTypes"`
+	Image                *string  `json:"Image"`
+	ImageName            *string  `json:"ImageName"`
+	Inserted             *bool    `json:"Inserted"`
+	WriteProtected       *bool    `json:"WriteProtected"`
+	ConnectedVia         *string  `json:"ConnectedVia"`
+	TransferProtocolType *string  `json:"TransferProtocolType"`
+	InsertMedia          *string  `json:"-"`
+	EjectMedia           *string  `json:"-"`
+	OemInsertMedia       *string  `json:"-"`
+	OemEjectMedia        *string  `json:"-"`
+	SelfEndpoint         *string
+}
+
+// actionTarget - target of an action
+type actionTarget struct {
+	Target *string `json:"target"`
+}
+
+type virtualMediaResource struct {
+	VirtualMediaData
+	Actions struct {
+		InsertMedia *actionTarget `json:"#VirtualMedia.InsertMedia"`
+		EjectMedia  *actionTarget `json:"#VirtualMedia.EjectMedia"`
+	} `json:"Actions"`
+	Oem map[string]struct {
+		Actions map[string]actionTarget `json:"Actions"`
+	} `json:"Oem"`
+}
+
+// VirtualMediaInsertData - image and options to insert into a virtual media slot
+type VirtualMediaInsertData struct {
+	Image                string
+	WriteProtected       bool
+	UserName             string
+	Password             string
+	TransferProtocolType string
+}
+
+// inserted - an image is inserted, some management boards only report the image and not Inserted
+func (vm *VirtualMediaData) inserted() bool {
+	if vm.Inserted != nil {
+		return *vm.Inserted
+	}
+	return vm.Image != nil && *vm.Image != ""
+}
+
+// supportsMediaType - slot supports one of the media types
+func (vm *VirtualMediaData) supportsMediaType(types ...string) bool {
+	for _, mt := range vm.MediaTypes {
+		for _, t := range types {
+			if strings.EqualFold(mt, t) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// fetchVirtualMedia - virtual media slots of a manager or system
+func fetchVirtualMedia(r redfish.Redfish, src resourceOwner) ([]*VirtualMediaData, error) {
+	var result = make([]*VirtualMediaData, 0)
+	var res struct {
+		VirtualMedia *OData `json:"VirtualMedia"`
+	}
+
+	err := redfishGet(r, src.Endpoint, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.VirtualMedia == nil || res.VirtualMedia.ID == nil {
+		return result, nil
+	}
+
+	members, err := collectionMembers(r, *res.VirtualMedia.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, member := range members {
+		var vm virtualMediaResource
+
+		err = redfishGet(r, member, &vm)
+		if err != nil {
+			return nil, err
+		}
+
+		ep := member
+		media := vm.VirtualMediaData
+		media.SourceType = src.Type
+		media.SourceID = src.ID
+		media.SelfEndpoint = &ep
+		if media.MediaTypes == nil {
+			media.MediaTypes = make([]string, 0)
+		}
+
+		if vm.Actions.InsertMedia != nil {
+			media.InsertMedia = vm.Actions.InsertMedia.Target
+		}
+		if vm.Actions.EjectMedia != nil {
+			media.EjectMedia = vm.Actions.EjectMedia.Target
+		}
+
+		// e.g. #HpeiLOVirtualMedia.InsertVirtualMedia of HPE or #HpiLOVirtualMedia.InsertVirtualMedia of HP
+		for _, oem := range vm.Oem {
+			for name, action := range oem.Actions {
+				if strings.HasSuffix(name, ".InsertVirtualMedia") {
+					media.OemInsertMedia = action.Target
+				}
+				if strings.HasSuffix(name, ".EjectVirtualMedia") {
+					media.OemEjectMedia = action.Target
+				}
+			}
+		}
+
+		result = append(result, &media)
+	}
+
+	return result, nil
+}
+
+// insertVirtualMedia - insert image into the virtual media slot using VirtualMedia.InsertMedia, the OEM action of the vendor
+// or, if neither is provided, by setting the Image of the slot
+func insertVirtualMedia(r redfish.Redfish, vm *VirtualMediaData, insert VirtualMediaInsertData) error {
+	var payload = make(map[string]interface{})
+	var err error
+
+	payload["Image"] = insert.Image
+
+	if vm.InsertMedia != nil {
+		payload["Inserted"] = true
+		payload["WriteProtected"] = insert.WriteProtected
+		if insert.UserName != "" {
+			payload["UserName"] = insert.UserName
+			payload["Password"] = insert.Password
+		}
+		if insert.TransferProtocolType != "" {
+			payload["TransferProtocolType"] = insert.TransferProtocolType
+		}
+		_, err = redfishSend(r, "POST", *vm.InsertMedia, payload)
+		return err
+	}
+
+	if insert.UserName != "" || insert.TransferProtocolType != "" {
+		return fmt.Errorf("ERROR: Credentials and transfer protocol require VirtualMedia.InsertMedia which is not provided by virtual media %s on %s", csvString(vm.ID), r.Hostname)
+	}
+
+	capa := vendorCapabilities[r.FlavorString]
+	if capa&HasOemVirtualMedia == HasOemVirtualMedia && vm.OemInsertMedia != nil {
+		_, err = redfishSend(r, "POST", *vm.OemInsertMedia, payload)
+		return err
+	}
+
+	payload["Inserted"] = true
+	payload["WriteProtected"] = insert.WriteProtected
+	_, err = redfishSend(r, "PATCH", *vm.SelfEndpoint, payload)
+	return err
+}
+
+// ejectVirtualMedia - eject the image from the virtual media slot using VirtualMedia.EjectMedia, the OEM action of the vendor
+// or, if neither is provided, by removing the Image of the slot
+func ejectVirtualMedia(r redfish.Redfish, vm *VirtualMediaData) error {
+	var payload = make(map[string]interface{})
+	var err error
+
+	if vm.EjectMedia != nil {
+		_, err = redfishSend(r, "POST", *vm.EjectMedia, payload)
+		return err
+	}
+
+	capa := vendorCapabilities[r.FlavorString]
+	if capa&HasOemVirtualMedia == HasOemVirtualMedia && vm.OemEjectMedia != nil {
+		_, err = redfishSend(r, "POST", *vm.OemEjectMedia, payload)
+		return err
+	}
+
+	payload["Image"] = nil
+	payload["Inserted"] = false
+	_, err = redfishSend(r, "PATCH", *vm.SelfEndpoint, payload)
+	return err
+}
+
+// selectVirtualMedia - virtual media slots of the managers and systems, only the slot with ID id if set
+func selectVirtualMedia(r redfish.Redfish, manager string, system string, id string) ([]*VirtualMediaData, error) {
+	var result = make([]*VirtualMediaData, 0)
+
+	owners, err := resourceOwners(r, manager, system)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, owner := range owners {
+		vlist, err := fetchVirtualMedia(r, owner)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, vm := range vlist {
+			if id != "" && (vm.ID == nil || *vm.ID != id) {
+				continue
+			}
+			result = append(result, vm)
+		}
+	}
+
+	if id != "" && len(result) == 0 {
+		return nil, fmt.Errorf("ERROR: Virtual media %s not found on %s", id, r.Hostname)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	redfish "git.ypbind.de/repository/go-redfish.git"
+	"io"
+	"time"
+)
+
+func waitTask(r redfish.Redfish, args []string, opts *OutputOptions, out io.Writer) error {
+	argParse := flag.NewFlagSet("wait-task", flag.ExitOnError)
+
+	var id = argParse.String("id", "", "Wait for task identified by ID")
+	var uri = argParse.String("uri", "", "Wait for task or task monitor identified by URI")
+	var waitTimeout = argParse.Uint("wait-timeout", 3600, "Time in seconds to wait for the task to finish")
+
+	argParse.Parse(args)
+
+	if *id != "" && *uri != "" {
+		return usageError("ERROR: Options -id and -uri are mutually exclusive")
+	}
+
+	if *id == "" && *uri == "" {
+		return usageError("ERROR: Required options -id or -uri not found")
+	}
+
+	// Initialize session
+	err := r.Initialise()
+	if err != nil {
+		return fmt.Errorf("ERROR: Initialisation failed for %s: %s", r.Hostname, err.Error())
+	}
+
+	// Login
+	err = r.Login()
+	if err != nil {
+		return fmt.Errorf("ERROR: Login to %s failed: %s", r.Hostname, err.Error())
+	}
+
+	defer r.Logout()
+
+	if *id != "" {
+		endpoint, err := taskEndpointByID(r, *id)
+		if err != nil {
+			return err
+		}
+		*uri = endpoint
+	}
+
+	task, err := waitForTask(r, *uri, time.Duration(*waitTimeout)*time.Second)
+	if task != nil {
+		output, perr := printTasks(r, []*TaskData{task}, opts)
+		if perr != nil {
+			return perr
+		}
+		fmt.Fprintln(out, output)
+	}
+	if err != nil {
+		return err
+	}
+
+	return task.failed(r)
+}